@@ -0,0 +1,272 @@
+package typego
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultAsyncQueueSize    = 1024
+	defaultAsyncWorkers      = 1
+	defaultAsyncFlushTimeout = 5 * time.Second
+)
+
+// OverflowPolicy decides what happens when the queue of typego.AsyncDispatcher is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the caller until the queue has room or the dispatcher is closed
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropNewest drops the entry being dispatched
+	OverflowDropNewest
+
+	// OverflowDropOldest drops the oldest queued entry to make room for the entry being dispatched
+	OverflowDropOldest
+)
+
+// AsyncConfig configures typego.AsyncDispatcher
+type AsyncConfig struct {
+	// QueueSize is the maximum number of queued entries. The default is 1024
+	QueueSize int
+
+	// Workers is the number of goroutines calling the handler. The default is 1
+	Workers int
+
+	// Overflow is the policy used when the queue is full. The default is OverflowBlock
+	Overflow OverflowPolicy
+
+	// FlushTimeout is the maximum time a fatal entry waits for the queued entries to be handled before the program
+	// exits. The default is 5 seconds
+	FlushTimeout time.Duration
+}
+
+// AsyncDispatcher calls a log handler asynchronously through a bounded queue, so a slow handler does not block the
+// caller of Log(). Until it is closed, a fatal entry waits for its queued entries before the program exits
+type AsyncDispatcher[T any] struct {
+	handler     func(entry T)
+	overflow    OverflowPolicy
+	queue       chan T
+	dropped     atomic.Uint64
+	done        chan struct{}
+	closing     chan struct{}
+	removeFlush func()
+
+	mu      sync.RWMutex
+	closed  bool
+	senders sync.WaitGroup
+
+	pendingMu sync.Mutex
+	pending   int
+	idle      chan struct{}
+}
+
+// NewAsyncDispatcher generates new typego.AsyncDispatcher that calls the handler from its worker goroutines
+func NewAsyncDispatcher[T any](handler func(entry T), config AsyncConfig) *AsyncDispatcher[T] {
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultAsyncQueueSize
+	}
+
+	if config.Workers <= 0 {
+		config.Workers = defaultAsyncWorkers
+	}
+
+	if config.FlushTimeout <= 0 {
+		config.FlushTimeout = defaultAsyncFlushTimeout
+	}
+
+	d := &AsyncDispatcher[T]{
+		handler:  handler,
+		overflow: config.Overflow,
+		queue:    make(chan T, config.QueueSize),
+		done:     make(chan struct{}),
+		closing:  make(chan struct{}),
+	}
+
+	d.removeFlush = addFlushHook(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), config.FlushTimeout)
+		defer cancel()
+
+		_ = d.Flush(ctx)
+	})
+
+	var wg sync.WaitGroup
+
+	wg.Add(config.Workers)
+
+	for i := 0; i < config.Workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			d.work()
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(d.done)
+	}()
+
+	return d
+}
+
+// NewAsyncErrorLog generates new typego.AsyncDispatcher wrapping the error log handler. Its Handle method can be used
+// by SetCustomErrorLog
+func NewAsyncErrorLog(handler ErrorLogHandler, config AsyncConfig) *AsyncDispatcher[Error] {
+	return NewAsyncDispatcher[Error](handler, config)
+}
+
+// NewAsyncInfoLog generates new typego.AsyncDispatcher wrapping the info log handler. Its Handle method can be used by
+// SetCustomInfoLog and the other level handler setters
+func NewAsyncInfoLog(handler InfoLogHandler, config AsyncConfig) *AsyncDispatcher[Info] {
+	return NewAsyncDispatcher[Info](handler, config)
+}
+
+// Handle queues the entry according to the overflow policy. The entry is dropped if the dispatcher is closed, even
+// while the caller is blocked by OverflowBlock
+func (d *AsyncDispatcher[T]) Handle(entry T) {
+	d.mu.RLock()
+
+	if d.closed {
+		d.mu.RUnlock()
+		d.dropped.Add(1)
+
+		return
+	}
+
+	// the queue is closed only after every sender is done, and a blocked sender must not hold the lock, or Close could
+	// not mark the dispatcher as closed to release it
+	d.senders.Add(1)
+	d.mu.RUnlock()
+
+	defer d.senders.Done()
+
+	d.addPending()
+
+	switch d.overflow {
+	case OverflowDropNewest:
+		select {
+		case d.queue <- entry:
+		default:
+			d.donePending()
+			d.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case d.queue <- entry:
+				return
+			default:
+			}
+
+			select {
+			case <-d.queue:
+				d.donePending()
+				d.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case d.queue <- entry:
+		case <-d.closing:
+			d.donePending()
+			d.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns the number of dropped entries
+func (d *AsyncDispatcher[T]) Dropped() uint64 {
+	return d.dropped.Load()
+}
+
+// Flush waits until every queued entry has been handled or the context is done
+func (d *AsyncDispatcher[T]) Flush(ctx context.Context) error {
+	d.pendingMu.Lock()
+
+	if d.pending == 0 {
+		d.pendingMu.Unlock()
+		return nil
+	}
+
+	idle := d.idle
+
+	d.pendingMu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting entries and waits until every queued entry has been handled or the context is done. The
+// entries of the callers blocked by OverflowBlock are dropped
+func (d *AsyncDispatcher[T]) Close(ctx context.Context) error {
+	d.mu.Lock()
+
+	if !d.closed {
+		d.closed = true
+		close(d.closing)
+		d.removeFlush()
+
+		go func() {
+			d.senders.Wait()
+			close(d.queue)
+		}()
+	}
+
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *AsyncDispatcher[T]) work() {
+	for entry := range d.queue {
+		d.handle(entry)
+		d.donePending()
+	}
+}
+
+func (d *AsyncDispatcher[T]) handle(entry T) {
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "typego: async log handler panic: %v\n", r)
+		}
+	}()
+
+	d.handler(entry)
+}
+
+func (d *AsyncDispatcher[T]) addPending() {
+	d.pendingMu.Lock()
+	defer d.pendingMu.Unlock()
+
+	if d.pending == 0 {
+		d.idle = make(chan struct{})
+	}
+
+	d.pending++
+}
+
+func (d *AsyncDispatcher[T]) donePending() {
+	d.pendingMu.Lock()
+	defer d.pendingMu.Unlock()
+
+	d.pending--
+
+	if d.pending == 0 {
+		close(d.idle)
+	}
+}
@@ -0,0 +1,338 @@
+package typego_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/dalikewara/typego"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type asyncRecorder struct {
+	mu      sync.Mutex
+	handled []int
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func newAsyncRecorder() *asyncRecorder {
+	return &asyncRecorder{
+		started: make(chan struct{}, 10),
+		gate:    make(chan struct{}),
+	}
+}
+
+func (r *asyncRecorder) handle(entry int) {
+	r.started <- struct{}{}
+	<-r.gate
+
+	r.mu.Lock()
+	r.handled = append(r.handled, entry)
+	r.mu.Unlock()
+}
+
+func (r *asyncRecorder) result() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fmt.Sprintf("%v", r.handled)
+}
+
+func TestNewAsyncDispatcher(t *testing.T) {
+	t.Run("block", func(t *testing.T) {
+		r := newAsyncRecorder()
+		d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{QueueSize: 1})
+
+		close(r.gate)
+
+		for i := 1; i <= 5; i++ {
+			d.Handle(i)
+		}
+
+		if err := d.Flush(context.Background()); err != nil {
+			log.Fatal("`err` must nil")
+		}
+
+		if result := r.result(); result != "[1 2 3 4 5]" {
+			log.Fatal("`result` must be `[1 2 3 4 5]`")
+		}
+
+		if dropped := d.Dropped(); dropped != 0 {
+			log.Fatal("`dropped` must be `0`")
+		}
+	})
+
+	t.Run("drop_newest", func(t *testing.T) {
+		r := newAsyncRecorder()
+		d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{QueueSize: 1, Overflow: typego.OverflowDropNewest})
+
+		d.Handle(1)
+		<-r.started
+		d.Handle(2)
+		d.Handle(3)
+
+		close(r.gate)
+
+		_ = d.Flush(context.Background())
+
+		if result := r.result(); result != "[1 2]" {
+			log.Fatal("`result` must be `[1 2]`")
+		}
+
+		if dropped := d.Dropped(); dropped != 1 {
+			log.Fatal("`dropped` must be `1`")
+		}
+	})
+
+	t.Run("drop_oldest", func(t *testing.T) {
+		r := newAsyncRecorder()
+		d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{QueueSize: 1, Overflow: typego.OverflowDropOldest})
+
+		d.Handle(1)
+		<-r.started
+		d.Handle(2)
+		d.Handle(3)
+
+		close(r.gate)
+
+		_ = d.Flush(context.Background())
+
+		if result := r.result(); result != "[1 3]" {
+			log.Fatal("`result` must be `[1 3]`")
+		}
+
+		if dropped := d.Dropped(); dropped != 1 {
+			log.Fatal("`dropped` must be `1`")
+		}
+	})
+
+	t.Run("workers", func(t *testing.T) {
+		r := newAsyncRecorder()
+		d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{Workers: 3})
+
+		for i := 1; i <= 3; i++ {
+			d.Handle(i)
+		}
+
+		for i := 1; i <= 3; i++ {
+			<-r.started
+		}
+
+		close(r.gate)
+
+		_ = d.Close(context.Background())
+
+		if handledLen := len(r.handled); handledLen != 3 {
+			log.Fatal("`handledLen` must be `3`")
+		}
+	})
+}
+
+func TestNewAsyncErrorLog(t *testing.T) {
+	var logged []string
+	var mu sync.Mutex
+
+	d := typego.NewAsyncErrorLog(func(err typego.Error) {
+		mu.Lock()
+		logged = append(logged, err.GetCode())
+		mu.Unlock()
+	}, typego.AsyncConfig{})
+
+	previous := typego.GetErrorLog()
+	t.Cleanup(func() {
+		typego.SetCustomErrorLog(previous)
+	})
+
+	typego.SetCustomErrorLog(d.Handle)
+
+	_ = typego.NewError("01", "general error").Log()
+	_ = typego.NewError("02", "general error").Log()
+
+	if err := d.Close(context.Background()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if fmt.Sprintf("%v", logged) != "[01 02]" {
+		log.Fatal("`logged` must be `[01 02]`")
+	}
+}
+
+func TestNewAsyncInfoLog(t *testing.T) {
+	var logged int
+
+	d := typego.NewAsyncInfoLog(func(info typego.Info) {
+		logged++
+	}, typego.AsyncConfig{})
+
+	d.Handle(typego.NewInfo())
+
+	_ = d.Flush(context.Background())
+
+	if logged != 1 {
+		log.Fatal("`logged` must be `1`")
+	}
+}
+
+func TestAsyncDispatcher_Flush(t *testing.T) {
+	r := newAsyncRecorder()
+	d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{})
+
+	if err := d.Flush(context.Background()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	d.Handle(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := d.Flush(ctx); err != context.DeadlineExceeded {
+		log.Fatal("`err` must be `context.DeadlineExceeded`")
+	}
+
+	close(r.gate)
+
+	if err := d.Flush(context.Background()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+}
+
+func TestAsyncDispatcher_Close(t *testing.T) {
+	r := newAsyncRecorder()
+	d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{})
+
+	close(r.gate)
+
+	d.Handle(1)
+
+	if err := d.Close(context.Background()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	d.Handle(2)
+
+	if err := d.Close(context.Background()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if result := r.result(); result != "[1]" {
+		log.Fatal("`result` must be `[1]`")
+	}
+
+	if dropped := d.Dropped(); dropped != 1 {
+		log.Fatal("`dropped` must be `1`")
+	}
+}
+
+func TestAsyncDispatcher_Close_blockedHandle(t *testing.T) {
+	r := newAsyncRecorder()
+	d := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{QueueSize: 1})
+
+	d.Handle(1)
+	<-r.started
+	d.Handle(2)
+
+	blocked := make(chan struct{})
+
+	go func() {
+		defer close(blocked)
+
+		d.Handle(3)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := d.Close(ctx); err != context.DeadlineExceeded {
+		log.Fatal("`err` must be `context.DeadlineExceeded`")
+	}
+
+	select {
+	case <-blocked:
+	case <-time.After(time.Second):
+		log.Fatal("the blocked Handle must return after Close")
+	}
+
+	close(r.gate)
+
+	if err := d.Close(context.Background()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if result := r.result(); result != "[1 2]" {
+		log.Fatal("`result` must be `[1 2]`")
+	}
+
+	if dropped := d.Dropped(); dropped != 1 {
+		log.Fatal("`dropped` must be `1`")
+	}
+}
+
+func TestAsyncDispatcher_fatalFlush(t *testing.T) {
+	typego.SetExit(func(code int) {})
+	defer typego.SetExit(os.Exit)
+
+	var handled atomic.Int64
+
+	d := typego.NewAsyncDispatcher(func(entry int) {
+		time.Sleep(10 * time.Millisecond)
+		handled.Add(1)
+	}, typego.AsyncConfig{})
+
+	d.Handle(1)
+	d.Handle(2)
+
+	_ = typego.NewFatal().Log()
+
+	if n := handled.Load(); n != 2 {
+		log.Fatal("`handled` must be `2`")
+	}
+
+	_ = d.Close(context.Background())
+
+	r := newAsyncRecorder()
+	closed := typego.NewAsyncDispatcher(r.handle, typego.AsyncConfig{FlushTimeout: time.Hour})
+
+	defer close(r.gate)
+
+	closed.Handle(1)
+	<-r.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_ = closed.Close(ctx)
+
+	start := time.Now()
+
+	_ = typego.NewFatal().Log()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		log.Fatal("a closed dispatcher must not be flushed by a fatal entry")
+	}
+}
+
+func TestAsyncDispatcher_Handle(t *testing.T) {
+	var handled int
+
+	d := typego.NewAsyncDispatcher(func(entry int) {
+		if entry == 1 {
+			panic("handler panic")
+		}
+
+		handled++
+	}, typego.AsyncConfig{})
+
+	d.Handle(1)
+	d.Handle(2)
+
+	_ = d.Close(context.Background())
+
+	if handled != 1 {
+		log.Fatal("`handled` must be `1`")
+	}
+}
@@ -0,0 +1,97 @@
+package typego_test
+
+import (
+	"encoding/json"
+	"github.com/dalikewara/typego"
+	"testing"
+)
+
+// The EncodingJSON benchmarks serialize the same data by encoding/json, which is how the entries were serialized
+// before the hand-written JSON writer, so they are the baseline of the allocations
+
+// Before the lazy info, the chain took 10 allocations, and adding a string to an existing error took 3 allocations:
+// the copy of the error, the new info slice and the variadic arguments. Adding plain strings must stay at that count
+
+func BenchmarkErrorModel_AddInfo(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = typego.NewError("01", "general error").AddInfo("raw info", 1).AddDebug("raw debug")
+	}
+}
+
+func BenchmarkErrorModel_AddInfo_string(b *testing.B) {
+	err := typego.NewError("01", "general error")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = err.AddInfo("raw info")
+	}
+}
+
+func BenchmarkErrorModel_AddInfo_Error(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = typego.NewError("01", "general error").AddInfo("raw info", 1).AddDebug("raw debug").Error()
+	}
+}
+
+func BenchmarkErrorModel_Error(b *testing.B) {
+	err := typego.NewError("01", "general error").AddInfo("raw info").AddField("user_id", 1).SetHttpStatus(500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkErrorModel_InternalJSON(b *testing.B) {
+	err := typego.ErrorFromData(fullErrorData(typego.LevelError))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = err.InternalJSON()
+	}
+}
+
+func BenchmarkErrorModel_InternalJSON_EncodingJSON(b *testing.B) {
+	data := fullErrorData(typego.LevelError)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		v, _ := json.Marshal(data)
+		_ = string(v)
+	}
+}
+
+func BenchmarkInfoModel_String(b *testing.B) {
+	info := typego.NewInfo().SetProcessID("123").AddInfo("raw info").AddField("user_id", 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = info.String()
+	}
+}
+
+func BenchmarkInfoModel_String_EncodingJSON(b *testing.B) {
+	data := typego.NewInfo().SetProcessID("123").AddInfo("raw info").AddField("user_id", 1).Data()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		v, _ := json.Marshal(data)
+		_ = string(v)
+	}
+}
@@ -0,0 +1,230 @@
+package typego
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	colorReset   = "\x1b[0m"
+	colorDim     = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+	colorGray    = "\x1b[90m"
+	colorBoldRed = "\x1b[1;31m"
+)
+
+// consoleLevelWidth is the width of the longest level name, so the headers are aligned
+const consoleLevelWidth = len(LevelWarning)
+
+// ConsoleSink writes every entry in a human-readable multi-line form, intended for local development:
+//
+//	2024-01-01T00:00:00Z ERROR   [01] general error
+//	    process_id   123
+//	    info         raw info
+//	    http_status  500
+//
+// The level is colorized when the writer is a terminal and the NO_COLOR environment variable is not set
+type ConsoleSink struct {
+	mu    sync.Mutex
+	w     io.Writer
+	color bool
+}
+
+// NewConsoleSink generates new typego.ConsoleSink that writes to w. Colors are enabled if w is a terminal and the
+// NO_COLOR environment variable is not set
+func NewConsoleSink(w io.Writer) *ConsoleSink {
+	return &ConsoleSink{
+		w:     w,
+		color: colorSupported(w),
+	}
+}
+
+// UseConsoleLog makes the default log handlers write the console form of typego.ConsoleSink to the standard output
+// instead of JSON lines. The sinks of the default pipeline are not changed, so the custom log handlers set by
+// SetCustomErrorLog and the other setters are still called for their levels, and no entry is lost while switching. Add
+// a typego.ConsoleSink to a pipeline to render the console form without the log handlers
+func UseConsoleLog() {
+	consoleLog.Store(NewConsoleSink(os.Stdout))
+}
+
+// UseJSONLog makes the default log handlers write JSON lines again after UseConsoleLog is called. JSON lines are
+// written by default
+func UseJSONLog() {
+	consoleLog.Store(nil)
+}
+
+// SetColor enables or disables colors and returns the sink
+func (s *ConsoleSink) SetColor(enabled bool) *ConsoleSink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.color = enabled
+
+	return s
+}
+
+// Write writes the entry in the console form
+func (s *ConsoleSink) Write(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := renderConsole(entry, s.color)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.w.Write(b)
+
+	return err
+}
+
+// renderConsole renders the header line followed by one aligned line per member. Every value of a list has its own
+// line
+func renderConsole(entry Entry, color bool) ([]byte, error) {
+	var buf bytes.Buffer
+
+	paint := func(c string, s string) {
+		if color {
+			buf.WriteString(c)
+			buf.WriteString(s)
+			buf.WriteString(colorReset)
+		} else {
+			buf.WriteString(s)
+		}
+	}
+
+	members := entryMembers(entry)
+	rest := make([]entryMember, 0, len(members))
+
+	var timestamp, code, message string
+
+	for _, m := range members {
+		switch m.key {
+		case "level":
+		case "timestamp":
+			timestamp = timestampString(m.value.(Timestamp))
+		case "code":
+			code, _ = m.value.(string)
+		case "message":
+			message, _ = m.value.(string)
+		default:
+			rest = append(rest, m)
+		}
+	}
+
+	if timestamp != "" {
+		paint(colorDim, timestamp)
+		buf.WriteByte(' ')
+	}
+
+	level := entry.GetLevel()
+	paint(levelColor(level), strings.ToUpper(level)+strings.Repeat(" ", max(0, consoleLevelWidth-len(level))))
+
+	if code != "" {
+		buf.WriteString(" [")
+		buf.WriteString(code)
+		buf.WriteByte(']')
+	}
+
+	if message != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(message)
+	}
+
+	buf.WriteByte('\n')
+
+	width := 0
+
+	for _, m := range rest {
+		width = max(width, len(m.key))
+	}
+
+	for _, m := range rest {
+		values, err := consoleValues(m.value)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, v := range values {
+			buf.WriteString("    ")
+
+			if i == 0 {
+				paint(colorDim, m.key)
+				buf.WriteString(strings.Repeat(" ", width-len(m.key)+2))
+			} else {
+				buf.WriteString(strings.Repeat(" ", width+2))
+			}
+
+			buf.WriteString(v)
+			buf.WriteByte('\n')
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// consoleValues returns the lines of the value. Strings are written as is and lists of strings have one line per
+// string, while other values are written as JSON
+func consoleValues(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case json.Number:
+		return []string{v.String()}, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{string(b)}, nil
+}
+
+func levelColor(level string) string {
+	switch level {
+	case LevelDebug:
+		return colorGray
+	case LevelInfo:
+		return colorGreen
+	case LevelNotice:
+		return colorCyan
+	case LevelWarning:
+		return colorYellow
+	case LevelError:
+		return colorRed
+	case LevelFatal:
+		return colorBoldRed
+	}
+
+	return colorMagenta
+}
+
+// colorSupported reports whether w is a terminal and the NO_COLOR environment variable is not set
+func colorSupported(w io.Writer) bool {
+	if v, ok := os.LookupEnv("NO_COLOR"); ok && v != "" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
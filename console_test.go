@@ -0,0 +1,149 @@
+package typego_test
+
+import (
+	"bytes"
+	"github.com/dalikewara/typego"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewConsoleSink(t *testing.T) {
+	t.Run("buffer", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := typego.NewError("01", "general error").SetProcessID("123").SetProcessName("checkout").AddInfo("raw info", "raw info 2").AddField("user_id", 1).SetHttpStatus(500).AddDebug("raw debug")
+
+		_ = typego.NewConsoleSink(&buf).Write(err)
+
+		if output := buf.String(); output != "2024-01-01T00:00:00Z ERROR   [01] general error\n"+
+			"    process_id    123\n"+
+			"    process_name  checkout\n"+
+			"    info          raw info\n"+
+			"                  raw info 2\n"+
+			"    user_id       1\n"+
+			"    http_status   500\n"+
+			"    debug         raw debug\n" {
+			log.Fatal("`output` must be the aligned console form")
+		}
+
+		buf.Reset()
+
+		_ = typego.NewConsoleSink(&buf).Write(typego.NewWarning())
+
+		if output := buf.String(); output != "2024-01-01T00:00:00Z WARNING\n" {
+			log.Fatal("`output` must be `2024-01-01T00:00:00Z WARNING`")
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "console.log"))
+		if err != nil {
+			log.Fatal("`err` must nil")
+		}
+		defer f.Close()
+
+		_ = typego.NewConsoleSink(f).Write(typego.NewInfo())
+
+		b, _ := os.ReadFile(f.Name())
+
+		if strings.Contains(string(b), "\x1b[") {
+			log.Fatal("`b` must not be colorized")
+		}
+	})
+
+	t.Run("no_color", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+
+		var buf bytes.Buffer
+
+		_ = typego.NewConsoleSink(&buf).Write(typego.NewInfo())
+
+		if strings.Contains(buf.String(), "\x1b[") {
+			log.Fatal("`buf` must not be colorized")
+		}
+	})
+}
+
+func TestConsoleSink_SetColor(t *testing.T) {
+	var buf bytes.Buffer
+
+	_ = typego.NewConsoleSink(&buf).SetColor(true).Write(typego.NewError("01", "general error").SetHttpStatus(500))
+
+	if output := buf.String(); output != "\x1b[2m2024-01-01T00:00:00Z\x1b[0m \x1b[31mERROR  \x1b[0m [01] general error\n    \x1b[2mhttp_status\x1b[0m  500\n" {
+		log.Fatal("`output` must be colorized")
+	}
+}
+
+func TestUseConsoleLog(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "console.log"))
+	if err != nil {
+		log.Fatal("`err` must nil")
+	}
+	defer f.Close()
+
+	stdout := os.Stdout
+	os.Stdout = f
+
+	typego.UseConsoleLog()
+
+	os.Stdout = stdout
+
+	defer typego.UseJSONLog()
+
+	var notices int
+
+	previous := typego.GetInfoLog(typego.LevelNotice)
+	t.Cleanup(func() {
+		typego.SetCustomNoticeLog(previous)
+	})
+
+	typego.SetCustomNoticeLog(func(info typego.Info) {
+		notices++
+	})
+
+	if sinks := typego.DefaultPipeline().Sinks(); len(sinks) != 1 || sinks[0] != typego.DefaultSinkName {
+		log.Fatal("`sinks` must be `[default]`")
+	}
+
+	typego.NewNotice().AddInfo("custom").Log()
+
+	if notices != 1 {
+		log.Fatal("the custom handler must still be called")
+	}
+
+	typego.DefaultErrorLog()(typego.NewError("01", "general error"))
+	typego.DefaultInfoLog()(typego.NewInfo().AddInfo("console"))
+
+	b, _ := os.ReadFile(f.Name())
+
+	if output := string(b); output != "2024-01-01T00:00:00Z ERROR   [01] general error\n2024-01-01T00:00:00Z INFO   \n    info  console\n" {
+		log.Fatal("`output` must be the console form, got `" + output + "`")
+	}
+}
+
+func TestUseJSONLog(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "json.log"))
+	if err != nil {
+		log.Fatal("`err` must nil")
+	}
+	defer f.Close()
+
+	typego.UseConsoleLog()
+	typego.UseJSONLog()
+
+	stdout := os.Stdout
+	os.Stdout = f
+
+	typego.DefaultErrorLog()(typego.NewError("01", "general error"))
+
+	os.Stdout = stdout
+
+	b, _ := os.ReadFile(f.Name())
+
+	if output := string(b); output != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}\n" {
+		log.Fatal("`output` must be the JSON line, got `" + output + "`")
+	}
+}
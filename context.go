@@ -0,0 +1,130 @@
+package typego
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextKey int
+
+const (
+	processIDContextKey contextKey = iota
+	processNameContextKey
+	fieldsContextKey
+)
+
+// WithProcessID returns a copy of the context that carries the process id
+func WithProcessID(ctx context.Context, processID string) context.Context {
+	return context.WithValue(ctx, processIDContextKey, processID)
+}
+
+// WithProcessName returns a copy of the context that carries the process name
+func WithProcessName(ctx context.Context, processName string) context.Context {
+	return context.WithValue(ctx, processNameContextKey, processName)
+}
+
+// WithFields returns a copy of the context that carries the fields in addition to the fields already carried by the
+// context. The fields are alternating keys and values, such as WithFields(ctx, "user_id", 1, "amount", 100)
+func WithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	if len(keysAndValues) == 0 {
+		return ctx
+	}
+
+	parent := FieldsFromContext(ctx)
+	fields := make([]any, 0, len(parent)+len(keysAndValues)+1)
+	fields = append(fields, parent...)
+	fields = append(fields, keysAndValues...)
+
+	if len(keysAndValues)%2 != 0 {
+		fields = append(fields, nil)
+	}
+
+	return context.WithValue(ctx, fieldsContextKey, fields)
+}
+
+// ProcessIDFromContext gets the process id carried by the context
+func ProcessIDFromContext(ctx context.Context) string {
+	processID, _ := ctx.Value(processIDContextKey).(string)
+	return processID
+}
+
+// ProcessNameFromContext gets the process name carried by the context
+func ProcessNameFromContext(ctx context.Context) string {
+	processName, _ := ctx.Value(processNameContextKey).(string)
+	return processName
+}
+
+// FieldsFromContext gets the alternating keys and values of the fields carried by the context
+func FieldsFromContext(ctx context.Context) []any {
+	fields, _ := ctx.Value(fieldsContextKey).([]any)
+	return fields
+}
+
+// NewErrorCtx generates new typego.Error with the process id, process name and fields carried by the context
+func NewErrorCtx(ctx context.Context, code string, message string) Error {
+	return newErrorModel(code, message, nil).fromContext(ctx)
+}
+
+// NewInfoCtx generates new typego.Info with the process id, process name and fields carried by the context
+func NewInfoCtx(ctx context.Context) Info {
+	i := newInfoModel(LevelInfo)
+	*i = i.fromContext(ctx)
+
+	return i
+}
+
+func (e *errorModel) fromContext(ctx context.Context) *errorModel {
+	c := e.clone()
+
+	if c.ProcessID == "" {
+		c.ProcessID = ProcessIDFromContext(ctx)
+	}
+
+	if c.ProcessName == "" {
+		c.ProcessName = ProcessNameFromContext(ctx)
+	}
+
+	c.Fields = mergeFields(c.Fields, contextFields(ctx, c.Fields))
+
+	return c
+}
+
+func (i infoModel) fromContext(ctx context.Context) infoModel {
+	if i.ProcessID == "" {
+		i.ProcessID = ProcessIDFromContext(ctx)
+	}
+
+	if i.ProcessName == "" {
+		i.ProcessName = ProcessNameFromContext(ctx)
+	}
+
+	i.Fields = mergeFields(i.Fields, contextFields(ctx, i.Fields))
+
+	return i
+}
+
+// contextFields gets the fields carried by the context that do not exist yet. A later field overrides an earlier
+// field with the same key
+func contextFields(ctx context.Context, existing map[string]any) map[string]any {
+	keysAndValues := FieldsFromContext(ctx)
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]any, len(keysAndValues)/2)
+
+	for j := 0; j+1 < len(keysAndValues); j += 2 {
+		key, ok := keysAndValues[j].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[j])
+		}
+
+		if _, ok := existing[key]; ok {
+			continue
+		}
+
+		fields[key] = keysAndValues[j+1]
+	}
+
+	return fields
+}
@@ -0,0 +1,100 @@
+package typego_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/dalikewara/typego"
+	"log"
+	"testing"
+)
+
+func TestWithProcessID(t *testing.T) {
+	if processID := typego.ProcessIDFromContext(typego.WithProcessID(context.Background(), "123")); processID != "123" {
+		log.Fatal("`processID` must be `123`")
+	}
+
+	if processID := typego.ProcessIDFromContext(context.Background()); processID != "" {
+		log.Fatal("`processID` must be ``")
+	}
+}
+
+func TestWithProcessName(t *testing.T) {
+	if processName := typego.ProcessNameFromContext(typego.WithProcessName(context.Background(), "test")); processName != "test" {
+		log.Fatal("`processName` must be `test`")
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	ctx := typego.WithFields(context.Background(), "user_id", 1)
+	ctx = typego.WithFields(ctx, "amount", 100, "odd")
+
+	if fields := typego.FieldsFromContext(ctx); fmt.Sprintf("%v", fields) != fmt.Sprintf("%v", []any{"user_id", 1, "amount", 100, "odd", nil}) {
+		log.Fatal("`fields` must be `[]any{\"user_id\", 1, \"amount\", 100, \"odd\", nil}`")
+	}
+
+	if fields := typego.FieldsFromContext(typego.WithFields(context.Background())); fields != nil {
+		log.Fatal("`fields` must nil")
+	}
+}
+
+func TestNewErrorCtx(t *testing.T) {
+	ctx := typego.WithFields(typego.WithProcessName(typego.WithProcessID(context.Background(), "123"), "test"), "user_id", 1)
+
+	if err := typego.NewErrorCtx(ctx, "01", "general error").Error(); err != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"process_name\":\"test\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"fields\":{\"user_id\":1}}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"process_name\":\"test\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"fields\":{\"user_id\":1}}`")
+	}
+}
+
+func TestNewInfoCtx(t *testing.T) {
+	ctx := typego.WithFields(typego.WithProcessID(context.Background(), "123"), "user_id", 1)
+
+	if info := typego.NewInfoCtx(ctx).String(); info != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"info\":null,\"fields\":{\"user_id\":1}}" {
+		log.Fatal("`info` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"info\":null,\"fields\":{\"user_id\":1}}`")
+	}
+}
+
+func TestErrorModel_LogCtx(t *testing.T) {
+	var logged typego.Error
+
+	previous := typego.GetErrorLog()
+	t.Cleanup(func() {
+		typego.SetCustomErrorLog(previous)
+	})
+
+	typego.SetCustomErrorLog(func(err typego.Error) {
+		logged = err
+	})
+
+	ctx := typego.WithFields(typego.WithProcessID(context.Background(), "123"), "user_id", 1)
+
+	_ = typego.NewError("01", "general error").SetProcessName("test").LogCtx(ctx)
+
+	if logged.Error() != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"process_name\":\"test\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"fields\":{\"user_id\":1}}" {
+		log.Fatal("`logged` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"process_name\":\"test\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"fields\":{\"user_id\":1}}`")
+	}
+
+	_ = typego.NewErrorCtx(ctx, "01", "general error").SetProcessID("456").AddField("user_id", 2).LogCtx(ctx)
+
+	if logged.GetProcessID() != "456" || logged.GetField("user_id") != 2 {
+		log.Fatal("`logged` must keep its process id and fields")
+	}
+}
+
+func TestInfoModel_LogCtx(t *testing.T) {
+	var logged typego.Info
+
+	previous := typego.GetInfoLog(typego.LevelInfo)
+	t.Cleanup(func() {
+		typego.SetCustomInfoLog(previous)
+	})
+
+	typego.SetCustomInfoLog(func(info typego.Info) {
+		logged = info
+	})
+
+	_ = typego.NewInfo().LogCtx(typego.WithProcessName(context.Background(), "test"))
+
+	if logged.GetProcessName() != "test" {
+		log.Fatal("`logged.GetProcessName()` must be `test`")
+	}
+}
@@ -0,0 +1,316 @@
+package typego
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Encoder encodes log entries. It is used by typego.Info.String(), the default log handlers and the writer sinks.
+// typego.Error.Error() is always JSON, so it can be parsed back
+type Encoder interface {
+	Encode(entry Entry) ([]byte, error)
+}
+
+// EncoderFunc adapts a function to typego.Encoder
+type EncoderFunc func(entry Entry) ([]byte, error)
+
+// Encode calls f(entry)
+func (f EncoderFunc) Encode(entry Entry) ([]byte, error) {
+	return f(entry)
+}
+
+var encoder = struct {
+	sync.RWMutex
+	value Encoder
+}{
+	value: jsonEncoder{},
+}
+
+// SetEncoder sets the global encoder. A nil encoder resets it to the JSON encoder, which is the default encoder
+func SetEncoder(enc Encoder) {
+	if enc == nil {
+		enc = jsonEncoder{}
+	}
+
+	encoder.Lock()
+	defer encoder.Unlock()
+
+	encoder.value = enc
+}
+
+// GetEncoder gets the global encoder
+func GetEncoder() Encoder {
+	encoder.RLock()
+	defer encoder.RUnlock()
+
+	return encoder.value
+}
+
+// NewJSONEncoder generates new typego.Encoder that encodes the entries as compact JSON objects
+func NewJSONEncoder() Encoder {
+	return jsonEncoder{}
+}
+
+// NewLogfmtEncoder generates new typego.Encoder that encodes the entries as logfmt lines, such as
+// `level=error timestamp=2024-01-01T00:00:00Z code=01 message="general error"`. The fields are encoded as top level
+// keys, and the lists, maps and structs are encoded as JSON values
+func NewLogfmtEncoder() Encoder {
+	return logfmtEncoder{}
+}
+
+// NewTextEncoder generates new typego.Encoder that encodes the entries as human-readable lines, such as
+// `2024-01-01T00:00:00Z ERROR [01] general error http_status=500`
+func NewTextEncoder() Encoder {
+	return textEncoder{}
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(entry Entry) ([]byte, error) {
+	switch v := entry.(type) {
+	case *errorModel:
+		return v.MarshalJSON()
+	case *infoModel:
+		return v.MarshalJSON()
+	}
+
+	return json.Marshal(entry)
+}
+
+type logfmtEncoder struct{}
+
+func (logfmtEncoder) Encode(entry Entry) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, m := range entryMembers(entry) {
+		if err := appendLogfmtPair(&buf, m.key, m.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+type textEncoder struct{}
+
+func (textEncoder) Encode(entry Entry) ([]byte, error) {
+	var buf bytes.Buffer
+
+	members := entryMembers(entry)
+	values := make(map[string]any, 4)
+
+	for _, m := range members {
+		switch m.key {
+		case "level", "timestamp", "code", "message":
+			values[m.key] = m.value
+		}
+	}
+
+	if t, ok := values["timestamp"].(Timestamp); ok {
+		buf.WriteString(timestampString(t))
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(strings.ToUpper(entry.GetLevel()))
+
+	if code, ok := values["code"].(string); ok {
+		buf.WriteString(" [")
+		buf.WriteString(code)
+		buf.WriteByte(']')
+	}
+
+	if message, ok := values["message"].(string); ok && message != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(message)
+	}
+
+	for _, m := range members {
+		switch m.key {
+		case "level", "timestamp", "code", "message":
+			continue
+		}
+
+		if err := appendLogfmtPair(&buf, m.key, m.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// entryMember is a member of an entry in the order of the JSON format
+type entryMember struct {
+	key   string
+	value any
+}
+
+// entryMembers returns the non empty members of the entry in the order of the JSON format. The fields are returned as
+// members sorted by key
+func entryMembers(entry Entry) []entryMember {
+	members := make([]entryMember, 0, 12)
+
+	add := func(key string, value any, empty bool) {
+		if !empty {
+			members = append(members, entryMember{key: key, value: value})
+		}
+	}
+
+	switch v := entry.(type) {
+	case Error:
+		d := v.Data()
+
+		add("level", d.Level, false)
+		add("timestamp", d.Timestamp, d.Timestamp == 0)
+		add("process_id", d.ProcessID, d.ProcessID == "")
+		add("process_name", d.ProcessName, d.ProcessName == "")
+		add("code", d.Code, false)
+		add("message", d.Message, false)
+		add("developer_message", d.DeveloperMessage, d.DeveloperMessage == "")
+		add("info", d.Info, len(d.Info) == 0)
+		members = appendFieldMembers(members, d.Fields)
+		add("http_status", d.HttpStatus, d.HttpStatus == 0)
+		add("rpc_status", d.RPCStatus, d.RPCStatus == 0)
+		add("debug", d.Debug, len(d.Debug) == 0)
+		add("stack", d.Stack, len(d.Stack) == 0)
+		add("suppressed", d.Suppressed, d.Suppressed == 0)
+	case Info:
+		d := v.Data()
+
+		add("level", d.Level, false)
+		add("timestamp", d.Timestamp, d.Timestamp == 0)
+		add("process_id", d.ProcessID, d.ProcessID == "")
+		add("process_name", d.ProcessName, d.ProcessName == "")
+		add("info", d.Info, len(d.Info) == 0)
+		members = appendFieldMembers(members, d.Fields)
+		add("debug", d.Debug, len(d.Debug) == 0)
+		add("duration_ms", d.DurationMS, d.DurationMS == 0)
+		add("suppressed", d.Suppressed, d.Suppressed == 0)
+	default:
+		timestamp := NewTimestamp(entry.GetTimestamp())
+
+		add("level", entry.GetLevel(), false)
+		add("timestamp", timestamp, timestamp == 0)
+		add("process_id", entry.GetProcessID(), entry.GetProcessID() == "")
+		add("process_name", entry.GetProcessName(), entry.GetProcessName() == "")
+		add("info", entry.GetInfo(), len(entry.GetInfo()) == 0)
+		members = appendFieldMembers(members, entry.GetFields())
+		add("debug", entry.GetDebug(), len(entry.GetDebug()) == 0)
+	}
+
+	return members
+}
+
+func appendFieldMembers(members []entryMember, fields map[string]any) []entryMember {
+	keys := make([]string, 0, len(fields))
+
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		members = append(members, entryMember{key: k, value: fields[k]})
+	}
+
+	return members
+}
+
+// appendLogfmtPair appends ` key=value`, without the leading space if the buffer is empty
+func appendLogfmtPair(buf *bytes.Buffer, key string, value any) error {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(logfmtString(key))
+	buf.WriteByte('=')
+
+	switch v := value.(type) {
+	case string:
+		buf.WriteString(logfmtString(v))
+	case Timestamp:
+		buf.WriteString(timestampString(v))
+	case json.Number:
+		buf.WriteString(v.String())
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		if len(b) > 0 && b[0] == '"' {
+			var s string
+
+			if err = json.Unmarshal(b, &s); err == nil {
+				buf.WriteString(logfmtString(s))
+				return nil
+			}
+		}
+
+		buf.WriteString(logfmtString(string(b)))
+	}
+
+	return nil
+}
+
+// logfmtString quotes the string if it is empty or contains spaces, quotes, equal signs or non printable characters
+func logfmtString(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	for _, r := range s {
+		if r == '"' || r == '=' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+
+	return s
+}
+
+// timestampString formats the timestamp according to the layout set by SetTimestampLayout
+func timestampString(t Timestamp) string {
+	b, err := t.MarshalJSON()
+	if err != nil {
+		return t.String()
+	}
+
+	return strings.Trim(string(b), `"`)
+}
+
+// encodeEntry encodes the entry with the global encoder, or returns the encoding error string
+func encodeEntry(entry Entry) string {
+	return encodeEntryWith(GetEncoder(), entry)
+}
+
+// encodeEntryWith encodes the entry with the encoder, or returns the encoding error string. The JSON encoder writes
+// typego.Error and typego.Info directly to the string
+func encodeEntryWith(enc Encoder, entry Entry) string {
+	if isJSONEncoder(enc) {
+		switch v := entry.(type) {
+		case *errorModel:
+			return v.InternalJSON()
+		case *infoModel:
+			return v.jsonString()
+		}
+	}
+
+	b, err := enc.Encode(entry)
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(b)
+}
+
+// isJSONEncoder reports whether the encoder is the JSON encoder
+func isJSONEncoder(enc Encoder) bool {
+	_, ok := enc.(jsonEncoder)
+	return ok
+}
@@ -0,0 +1,122 @@
+package typego_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"testing"
+)
+
+func TestSetEncoder(t *testing.T) {
+	defer typego.SetEncoder(nil)
+
+	typego.SetEncoder(typego.NewLogfmtEncoder())
+
+	if err, _ := typego.GetEncoder().Encode(typego.NewError("01", "general error")); string(err) != "level=error timestamp=2024-01-01T00:00:00Z code=01 message=\"general error\"" {
+		log.Fatal("`err` must be `level=error timestamp=2024-01-01T00:00:00Z code=01 message=\"general error\"`")
+	}
+
+	if err := typego.NewError("01", "general error").Error(); err != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}`")
+	}
+
+	if info := typego.NewInfo().AddInfo("raw info").String(); info != "level=info timestamp=2024-01-01T00:00:00Z info=\"[\\\"raw info\\\"]\"" {
+		log.Fatal("`info` must be `level=info timestamp=2024-01-01T00:00:00Z info=\"[\\\"raw info\\\"]\"`")
+	}
+
+	typego.SetEncoder(nil)
+
+	if err := typego.NewError("01", "general error").Error(); err != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}`")
+	}
+}
+
+func TestGetEncoder(t *testing.T) {
+	if enc := typego.GetEncoder(); enc == nil {
+		log.Fatal("`enc` must not nil")
+	}
+}
+
+func TestNewJSONEncoder(t *testing.T) {
+	b, _ := typego.NewJSONEncoder().Encode(typego.NewWarning().AddField("user_id", 1))
+
+	if string(b) != "{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null,\"fields\":{\"user_id\":1}}" {
+		log.Fatal("`b` must be `{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null,\"fields\":{\"user_id\":1}}`")
+	}
+}
+
+func TestNewLogfmtEncoder(t *testing.T) {
+	err := typego.NewError("01", "general error").SetProcessID("123").AddFields(map[string]any{"user_id": 1, "path": "/users", "query": "a=b", "tags": []string{"a"}}).SetHttpStatus(500)
+
+	b, _ := typego.NewLogfmtEncoder().Encode(err)
+
+	if string(b) != "level=error timestamp=2024-01-01T00:00:00Z process_id=123 code=01 message=\"general error\" path=/users query=\"a=b\" tags=\"[\\\"a\\\"]\" user_id=1 http_status=500" {
+		log.Fatal("`b` must be `level=error timestamp=2024-01-01T00:00:00Z process_id=123 code=01 message=\"general error\" path=/users query=\"a=b\" tags=\"[\\\"a\\\"]\" user_id=1 http_status=500`")
+	}
+
+	typego.SetTimestampLayout(typego.TimestampUnixMilli)
+	defer typego.SetTimestampLayout(typego.TimestampRFC3339Nano)
+
+	b, _ = typego.NewLogfmtEncoder().Encode(typego.NewNotice().Finish())
+
+	if string(b) != "level=notice timestamp=1704067200000" {
+		log.Fatal("`b` must be `level=notice timestamp=1704067200000`")
+	}
+}
+
+func TestNewTextEncoder(t *testing.T) {
+	b, _ := typego.NewTextEncoder().Encode(typego.NewError("01", "general error").AddField("user_id", 1).SetHttpStatus(500))
+
+	if string(b) != "2024-01-01T00:00:00Z ERROR [01] general error user_id=1 http_status=500" {
+		log.Fatal("`b` must be `2024-01-01T00:00:00Z ERROR [01] general error user_id=1 http_status=500`")
+	}
+
+	b, _ = typego.NewTextEncoder().Encode(typego.NewInfo().SetProcessName("worker"))
+
+	if string(b) != "2024-01-01T00:00:00Z INFO process_name=worker" {
+		log.Fatal("`b` must be `2024-01-01T00:00:00Z INFO process_name=worker`")
+	}
+}
+
+func TestEncoderFunc_Encode(t *testing.T) {
+	enc := typego.EncoderFunc(func(entry typego.Entry) ([]byte, error) {
+		return []byte(entry.GetLevel()), nil
+	})
+
+	if b, _ := enc.Encode(typego.NewInfo()); string(b) != "info" {
+		log.Fatal("`b` must be `info`")
+	}
+
+	typego.SetEncoder(typego.EncoderFunc(func(entry typego.Entry) ([]byte, error) {
+		return nil, errors.New("encode error")
+	}))
+	defer typego.SetEncoder(nil)
+
+	if info := typego.NewInfo().String(); info != "encode error" {
+		log.Fatal("`info` must be `encode error`")
+	}
+}
+
+func TestWriterSink_SetEncoder(t *testing.T) {
+	var buf bytes.Buffer
+
+	sink := typego.NewWriterSink(&buf).SetEncoder(typego.NewTextEncoder())
+
+	_ = sink.Write(typego.NewError("01", "general error"))
+
+	if output := buf.String(); output != "2024-01-01T00:00:00Z ERROR [01] general error\n" {
+		log.Fatal("`output` must be `2024-01-01T00:00:00Z ERROR [01] general error`")
+	}
+
+	buf.Reset()
+
+	typego.SetEncoder(typego.NewLogfmtEncoder())
+	defer typego.SetEncoder(nil)
+
+	_ = sink.SetEncoder(nil).Write(typego.NewError("01", "general error"))
+
+	if output := buf.String(); output != "level=error timestamp=2024-01-01T00:00:00Z code=01 message=\"general error\"\n" {
+		log.Fatal("`output` must be `level=error timestamp=2024-01-01T00:00:00Z code=01 message=\"general error\"`")
+	}
+}
@@ -1,21 +1,76 @@
 package typego
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
 )
 
+const defaultErrorCode = "UNKNOWN"
+
+// ErrNilError is returned when parsing a nil error
+var ErrNilError = errors.New("typego: nil error")
+
+// ErrInvalidFormat is returned when parsing an error that does not have the typego.Error string format
+var ErrInvalidFormat = errors.New("typego: invalid error format")
+
+var defaultErrorCodeValue = struct {
+	sync.RWMutex
+	value string
+}{
+	value: defaultErrorCode,
+}
+
+// SetDefaultErrorCode sets the code of the errors generated from non typego errors, such as by NewErrorFromError and
+// Middleware. The default code is `UNKNOWN`
+func SetDefaultErrorCode(code string) {
+	defaultErrorCodeValue.Lock()
+	defer defaultErrorCodeValue.Unlock()
+
+	defaultErrorCodeValue.value = code
+}
+
+// GetDefaultErrorCode gets the code of the errors generated from non typego errors
+func GetDefaultErrorCode() string {
+	defaultErrorCodeValue.RLock()
+	defer defaultErrorCodeValue.RUnlock()
+
+	return defaultErrorCodeValue.value
+}
+
 type Error interface {
 	// ChangeCode changes error code and returns its instance
 	ChangeCode(code string) Error
 
-	// ChangeMessage changes error message and returns its instance
+	// ChangeMessage changes error message and returns its instance. The message is shown to the client
 	ChangeMessage(message string) Error
 
-	// AddInfo adds error information and returns its instance
+	// SetDeveloperMessage sets the developer message and returns its instance. The developer message is never shown to
+	// the client
+	SetDeveloperMessage(developerMessage string) Error
+
+	// Localize changes error message to the message of the error code in the locale from the default bundle and
+	// returns its instance. The message is kept if the default bundle has no message of the error code
+	Localize(locale string) Error
+
+	// AddInfo adds error information and returns its instance. The values are stringified with the redactor and the
+	// JSON string mode active when they are added. The stringification of the scalar values is deferred until the
+	// information is first read, encoded or logged
 	AddInfo(info ...any) Error
 
-	// AddDebug adds debug information and returns its instance
+	// AddField adds a structured field and returns its instance. The field value keeps its type when serialized
+	AddField(key string, value any) Error
+
+	// AddFields adds structured fields and returns its instance. The field values keep their types when serialized
+	AddFields(fields map[string]any) Error
+
+	// AddDebug adds debug information and returns its instance. The values are stringified like AddInfo
 	AddDebug(debug ...any) Error
 
 	// SetProcessID sets process id
@@ -30,6 +85,18 @@ type Error interface {
 	// SetRPCStatus sets error rpc status and returns its instance
 	SetRPCStatus(rpcStatus int) Error
 
+	// WithStack captures the current stack trace and returns its instance
+	WithStack() Error
+
+	// WithoutStack removes the captured stack trace and returns its instance
+	WithoutStack() Error
+
+	// GetLevel gets error level
+	GetLevel() string
+
+	// GetTimestamp gets the creation time
+	GetTimestamp() time.Time
+
 	// GetProcessID gets process id
 	GetProcessID() string
 
@@ -42,6 +109,15 @@ type Error interface {
 	// GetMessage gets error message
 	GetMessage() string
 
+	// GetDeveloperMessage gets the developer message
+	GetDeveloperMessage() string
+
+	// GetField gets the structured field value of the key, or nil if the field does not exist
+	GetField(key string) any
+
+	// GetFields gets a copy of the structured fields
+	GetFields() map[string]any
+
 	// GetInfo gets error information
 	GetInfo() []string
 
@@ -54,142 +130,390 @@ type Error interface {
 	// GetRPCStatus gets error rpc status
 	GetRPCStatus() int
 
+	// GetStack gets the captured stack trace. It is encoded as the `stack` member next to the `debug` member, not
+	// inside it, and it is not part of the public view
+	GetStack() []string
+
+	// GetSuppressed gets the number of entries suppressed by the log limit before the error was logged
+	GetSuppressed() uint64
+
+	// Data gets a copy of the error data
+	Data() ErrorData
+
 	// Log logs the error and return its instance
 	Log() Error
 
-	// Error returns error string
+	// LogCtx logs the error with the process id, process name and fields carried by the context, and return its
+	// instance
+	LogCtx(ctx context.Context) Error
+
+	// Unwrap returns the underlying cause of the error
+	Unwrap() error
+
+	// Is reports whether the target is a typego.Error with the same code
+	Is(target error) bool
+
+	// As sets the target to the error if the target is a *typego.Error
+	As(target any) bool
+
+	// PublicJSON returns the client facing JSON of the error, without the developer message, fields, debug
+	// information and stack trace
+	PublicJSON() string
+
+	// InternalJSON returns the complete JSON of the error
+	InternalJSON() string
+
+	// Error returns error string, which is the complete JSON of the error like InternalJSON. It is not changed by the
+	// global encoder or production mode, so it can always be parsed by ParseError. Use PublicJSON at the API boundaries
 	Error() string
 }
 
+// ErrorData is the data of typego.Error. It can be embedded, encoded and decoded, and converted back to typego.Error
+// by ErrorFromData. Decode it with json.Decoder.UseNumber to keep the precision of the numbers in the fields
+type ErrorData struct {
+	Level            string         `json:"level"`
+	Timestamp        Timestamp      `json:"timestamp,omitempty"`
+	ProcessID        string         `json:"process_id,omitempty"`
+	ProcessName      string         `json:"process_name,omitempty"`
+	Code             string         `json:"code"`
+	Message          string         `json:"message"`
+	DeveloperMessage string         `json:"developer_message,omitempty"`
+	Info             []string       `json:"info"`
+	Fields           map[string]any `json:"fields,omitempty"`
+	HttpStatus       int            `json:"http_status,omitempty"`
+	RPCStatus        int            `json:"rpc_status,omitempty"`
+	Debug            []string       `json:"debug,omitempty"`
+	Stack            []string       `json:"stack,omitempty"`
+	Suppressed       uint64         `json:"suppressed,omitempty"`
+}
+
 type errorModel struct {
-	Level       string   `json:"level"`
-	ProcessID   string   `json:"process_id,omitempty"`
-	ProcessName string   `json:"process_name,omitempty"`
-	Code        string   `json:"code"`
-	Message     string   `json:"message"`
-	Info        []string `json:"info"`
-	HttpStatus  int      `json:"http_status,omitempty"`
-	RPCStatus   int      `json:"rpc_status,omitempty"`
-	Debug       []string `json:"debug,omitempty"`
-}
-
-func (e errorModel) SetProcessID(processID string) Error {
-	e.ProcessID = processID
-	return e
+	ErrorData
+	cause error
+	info  *lazyStrings
+	debug *lazyStrings
+	cache errorCache
 }
 
-func (e errorModel) ChangeCode(code string) Error {
-	e.Code = code
-	return e
+// MarshalJSON encodes the error in the typego.Error JSON format
+func (e *errorModel) MarshalJSON() ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	d := e.data()
+
+	b, err := appendErrorJSON(*buf, &d, e.rawStrings())
+	*buf = b
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), b...), nil
 }
 
-func (e errorModel) ChangeMessage(message string) Error {
-	e.Message = message
-	return e
+// UnmarshalJSON decodes the error from the typego.Error JSON format. The numbers in the fields are decoded as
+// json.Number, so they keep their precision. A raw JSON value in the information or debug is decoded as its JSON
+// string, and it is embedded as raw JSON again when the error is encoded
+func (e *errorModel) UnmarshalJSON(b []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	d := struct {
+		*ErrorData
+		Info  jsonStrings `json:"info"`
+		Debug jsonStrings `json:"debug"`
+	}{
+		ErrorData: &e.ErrorData,
+	}
+
+	e.cache.reset()
+
+	if err := decoder.Decode(&d); err != nil {
+		return err
+	}
+
+	e.Info, e.info = d.Info.lazy()
+	e.Debug, e.debug = d.Debug.lazy()
+
+	return nil
 }
 
-func (e errorModel) AddInfo(info ...any) Error {
-	additionalInfo := make([]string, 0, len(info))
-
-	for _, i := range info {
-		switch v := i.(type) {
-		case string:
-			additionalInfo = append(additionalInfo, JSONStringCleaner(v))
-		case error:
-			additionalInfo = append(additionalInfo, JSONStringCleaner(v.Error()))
-		default:
-			jsonValue, err := json.Marshal(v)
-			if err != nil {
-				additionalInfo = append(additionalInfo, fmt.Sprintf("%+v", v))
-			} else {
-				additionalInfo = append(additionalInfo, string(jsonValue))
-			}
-		}
+func (e *errorModel) SetProcessID(processID string) Error {
+	c := e.clone()
+	c.ProcessID = processID
+
+	return c
+}
+
+func (e *errorModel) ChangeCode(code string) Error {
+	c := e.clone()
+	c.Code = code
+
+	return c
+}
+
+func (e *errorModel) ChangeMessage(message string) Error {
+	c := e.clone()
+	c.Message = message
+
+	return c
+}
+
+func (e *errorModel) SetDeveloperMessage(developerMessage string) Error {
+	c := e.clone()
+	c.DeveloperMessage = developerMessage
+
+	return c
+}
+
+func (e *errorModel) Localize(locale string) Error {
+	message, ok := DefaultBundle().Message(locale, e.Code, e.Fields)
+	if !ok {
+		return e
 	}
 
-	e.Info = append(e.Info, additionalInfo...)
+	c := e.clone()
+	c.Message = message
 
-	return e
+	return c
 }
 
-func (e errorModel) AddDebug(debug ...any) Error {
-	additionalDebug := make([]string, 0, len(debug))
-
-	for _, i := range debug {
-		switch v := i.(type) {
-		case string:
-			additionalDebug = append(additionalDebug, JSONStringCleaner(v))
-		case error:
-			additionalDebug = append(additionalDebug, JSONStringCleaner(v.Error()))
-		default:
-			jsonValue, err := json.Marshal(v)
-			if err != nil {
-				additionalDebug = append(additionalDebug, fmt.Sprintf("%+v", v))
-			} else {
-				additionalDebug = append(additionalDebug, string(jsonValue))
-			}
-		}
+func (e *errorModel) AddInfo(info ...any) Error {
+	if len(info) == 0 {
+		return e
 	}
 
-	e.Debug = append(e.Debug, additionalDebug...)
+	c := e.clone()
+	c.Info, c.info = addStrings(c.info, c.Info, info)
 
-	return e
+	return c
 }
 
-func (e errorModel) SetProcessName(processName string) Error {
-	e.ProcessName = processName
-	return e
+func (e *errorModel) AddField(key string, value any) Error {
+	c := e.clone()
+	c.Fields = mergeFields(c.Fields, map[string]any{key: value})
+
+	return c
 }
 
-func (e errorModel) SetHttpStatus(httpStatus int) Error {
-	e.HttpStatus = httpStatus
-	return e
+func (e *errorModel) AddFields(fields map[string]any) Error {
+	c := e.clone()
+	c.Fields = mergeFields(c.Fields, fields)
+
+	return c
 }
 
-func (e errorModel) SetRPCStatus(rpcStatus int) Error {
-	e.RPCStatus = rpcStatus
-	return e
+func (e *errorModel) AddDebug(debug ...any) Error {
+	if len(debug) == 0 {
+		return e
+	}
+
+	c := e.clone()
+	c.Debug, c.debug = addStrings(c.debug, c.Debug, debug)
+
+	return c
+}
+
+func (e *errorModel) SetProcessName(processName string) Error {
+	c := e.clone()
+	c.ProcessName = processName
+
+	return c
+}
+
+func (e *errorModel) SetHttpStatus(httpStatus int) Error {
+	c := e.clone()
+	c.HttpStatus = httpStatus
+
+	return c
+}
+
+func (e *errorModel) SetRPCStatus(rpcStatus int) Error {
+	c := e.clone()
+	c.RPCStatus = rpcStatus
+
+	return c
+}
+
+func (e *errorModel) WithStack() Error {
+	c := e.clone()
+	c.Stack = captureStack(1)
+
+	return c
+}
+
+func (e *errorModel) WithoutStack() Error {
+	c := e.clone()
+	c.Stack = nil
+
+	return c
+}
+
+func (e *errorModel) GetLevel() string {
+	return e.Level
 }
 
-func (e errorModel) GetProcessID() string {
+func (e *errorModel) GetTimestamp() time.Time {
+	return e.Timestamp.Time()
+}
+
+func (e *errorModel) GetProcessID() string {
 	return e.ProcessID
 }
 
-func (e errorModel) GetProcessName() string {
+func (e *errorModel) GetProcessName() string {
 	return e.ProcessName
 }
 
-func (e errorModel) GetCode() string {
+func (e *errorModel) GetCode() string {
 	return e.Code
 }
 
-func (e errorModel) GetMessage() string {
+func (e *errorModel) GetMessage() string {
 	return e.Message
 }
 
-func (e errorModel) GetInfo() []string {
+func (e *errorModel) GetDeveloperMessage() string {
+	return e.DeveloperMessage
+}
+
+func (e *errorModel) GetField(key string) any {
+	return e.Fields[key]
+}
+
+func (e *errorModel) GetFields() map[string]any {
+	return copyFields(e.Fields)
+}
+
+func (e *errorModel) GetInfo() []string {
+	if e.info != nil {
+		return e.info.resolve()
+	}
+
 	return e.Info
 }
 
-func (e errorModel) GetDebug() []string {
+func (e *errorModel) GetDebug() []string {
+	if e.debug != nil {
+		return e.debug.resolve()
+	}
+
 	return e.Debug
 }
 
-func (e errorModel) GetHttpStatus() int {
+func (e *errorModel) GetHttpStatus() int {
 	return e.HttpStatus
 }
 
-func (e errorModel) GetRPCStatus() int {
+func (e *errorModel) GetRPCStatus() int {
 	return e.RPCStatus
 }
 
-func (e errorModel) Log() Error {
-	errorLogHandler(e)
+func (e *errorModel) GetStack() []string {
+	return e.Stack
+}
+
+func (e *errorModel) GetSuppressed() uint64 {
+	return e.Suppressed
+}
+
+func (e *errorModel) Data() ErrorData {
+	d := e.data()
+	d.Info = copyStrings(d.Info)
+	d.Fields = copyFields(d.Fields)
+	d.Debug = copyStrings(d.Debug)
+	d.Stack = copyStrings(d.Stack)
+
+	return d
+}
+
+// data gets the error data with the lazy information stringified. The data shares its slices and fields with the error
+func (e *errorModel) data() ErrorData {
+	d := e.ErrorData
+	d.Info = e.GetInfo()
+	d.Debug = e.GetDebug()
+
+	return d
+}
+
+// rawStrings gets the marks of the information and debug strings embedded as raw JSON
+func (e *errorModel) rawStrings() rawStrings {
+	return rawStrings{
+		info:  e.info.rawMarks(),
+		debug: e.debug.rawMarks(),
+	}
+}
+
+// clone returns a copy of the error to be changed by a builder. The cache of typego.Error.Error() is not copied, so
+// the changed error does not share it with the original, and the copy is the only allocation of the builder
+func (e *errorModel) clone() *errorModel {
+	return &errorModel{
+		ErrorData: e.ErrorData,
+		cause:     e.cause,
+		info:      e.info,
+		debug:     e.debug,
+	}
+}
+
+func (e *errorModel) Log() Error {
+	logError(e)
+	return e
+}
+
+func (e *errorModel) LogCtx(ctx context.Context) Error {
+	logError(e.fromContext(ctx))
 	return e
 }
 
-func (e errorModel) Error() string {
-	b, err := json.Marshal(e)
+func (e *errorModel) Unwrap() error {
+	return e.cause
+}
+
+func (e *errorModel) Is(target error) bool {
+	t, ok := target.(Error)
+	if !ok {
+		return false
+	}
+
+	return t.GetCode() == e.Code
+}
+
+func (e *errorModel) As(target any) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	*t = e
+
+	return true
+}
+
+func (e *errorModel) PublicJSON() string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	*buf = appendPublicErrorJSON(*buf, &publicError{
+		ProcessID: e.ProcessID,
+		Code:      e.Code,
+		Message:   e.Message,
+		Info:      e.GetInfo(),
+		infoRaw:   e.info.rawMarks(),
+	})
+
+	return string(*buf)
+}
+
+func (e *errorModel) InternalJSON() string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	d := e.data()
+
+	b, err := appendErrorJSON(*buf, &d, e.rawStrings())
+	*buf = b
+
 	if err != nil {
 		return err.Error()
 	}
@@ -197,23 +521,145 @@ func (e errorModel) Error() string {
 	return string(b)
 }
 
-// NewError generates new typego.Error
+func (e *errorModel) Error() string {
+	generation := encodingGeneration.Load()
+
+	if s, ok := e.cache.load(generation); ok {
+		return s
+	}
+
+	s := e.InternalJSON()
+
+	e.cache.store(generation, s)
+
+	return s
+}
+
+// Format implements fmt.Formatter. The %+v verb prints the error string without the `stack` member, followed by the
+// captured stack trace line by line, so the stack trace is printed once
+func (e *errorModel) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && len(e.Stack) > 0 {
+			c := e.clone()
+			c.Stack = nil
+
+			_, _ = io.WriteString(s, c.Error())
+
+			for _, frame := range e.Stack {
+				_, _ = io.WriteString(s, "\n\t"+frame)
+			}
+
+			return
+		}
+
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(typego.Error=%s)", verb, e.Error())
+	}
+}
+
+// NewError generates new typego.Error. The stack trace is captured when stack capture is enabled globally by
+// SetStackCapture
 func NewError(code string, message string) Error {
-	return &errorModel{
-		Level:   "error",
-		Code:    code,
-		Message: message,
+	return newErrorModel(code, message, nil)
+}
+
+// ErrorFromData generates new typego.Error from a copy of the error data. The level defaults to `error` when it is
+// empty
+func ErrorFromData(data ErrorData) Error {
+	e := errorModel{
+		ErrorData: (&errorModel{ErrorData: data}).Data(),
 	}
+
+	if e.Level == "" {
+		e.Level = LevelError
+	}
+
+	return &e
+}
+
+// Wrap generates new typego.Error that wraps the given cause, so the cause stays reachable by errors.Is and errors.As
+func Wrap(cause error, code string, message string) Error {
+	return newErrorModel(code, message, cause)
 }
 
-// NewErrorFromError generates new typego.Error from an error. The error.Error() must have the same string format as
-// typego.Error.Error(), otherwise, typego.Error will return incorrect value
+// NewErrorFromError generates new typego.Error from an error. If the error chain contains a typego.Error, it returns
+// the typego.Error. If the error.Error() has the same string format as typego.Error.Error(), it returns the parsed
+// typego.Error. Otherwise, it generates new typego.Error wrapping the error with the default error code and the error
+// string as the message. It returns nil if the error is nil
 func NewErrorFromError(err error) Error {
+	if err == nil {
+		return nil
+	}
+
+	if e, er := ParseError(err); er == nil {
+		return e
+	}
+
+	return newErrorModel(GetDefaultErrorCode(), err.Error(), err)
+}
+
+// newErrorModel generates new error model with the level `error` and the current timestamp, and captures the stack
+// trace if stack capture is enabled. It must be called directly by the exported constructors, so the stack trace starts
+// at their caller
+func newErrorModel(code string, message string, cause error) *errorModel {
+	e := &errorModel{
+		ErrorData: ErrorData{
+			Level:     LevelError,
+			Timestamp: NewTimestamp(now()),
+			Code:      code,
+			Message:   message,
+		},
+		cause: cause,
+	}
+
+	if stackCaptureEnabled.Load() {
+		e.Stack = captureStack(2)
+	}
+
+	return e
+}
+
+// ParseError is like NewErrorFromError but returns an error instead of the fallback typego.Error. It returns
+// ErrNilError if the error is nil and ErrInvalidFormat if the error.Error() is not a typego.Error JSON object with code
+func ParseError(err error) (Error, error) {
+	if err == nil {
+		return nil, ErrNilError
+	}
+
+	var target Error
+
+	if errors.As(err, &target) {
+		return target, nil
+	}
+
 	var e errorModel
 
-	if er := json.Unmarshal([]byte(err.Error()), &e); er != nil {
-		return &e
+	decoder := json.NewDecoder(strings.NewReader(err.Error()))
+	decoder.UseNumber()
+
+	if er := decoder.Decode(&e); er != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, er)
 	}
 
-	return &e
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: unexpected data after the JSON object", ErrInvalidFormat)
+	}
+
+	if e.Code == "" {
+		return nil, fmt.Errorf("%w: missing code", ErrInvalidFormat)
+	}
+
+	if e.Level == "" {
+		e.Level = LevelError
+	}
+
+	e.cause = err
+
+	return &e, nil
 }
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/dalikewara/typego"
+	"io"
+	"io/fs"
 	"log"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -34,12 +37,30 @@ func TestErrorModel_ChangeMessage(t *testing.T) {
 	}
 }
 
+func TestErrorModel_SetDeveloperMessage(t *testing.T) {
+	if err := typego.NewError("", "").SetDeveloperMessage("database timeout"); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
 func TestErrorModel_AddInfo(t *testing.T) {
 	if err := typego.NewError("", "").AddInfo(errors.New("raw error")); err == nil {
 		log.Fatal("`err` must not nil")
 	}
 }
 
+func TestErrorModel_AddField(t *testing.T) {
+	if err := typego.NewError("", "").AddField("user_id", 1); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestErrorModel_AddFields(t *testing.T) {
+	if err := typego.NewError("", "").AddFields(map[string]any{"user_id": 1}); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
 func TestErrorModel_AddDebug(t *testing.T) {
 	if err := typego.NewError("", "").AddDebug(errors.New("raw error")); err == nil {
 		log.Fatal("`err` must not nil")
@@ -70,6 +91,30 @@ func TestErrorModel_SetRPCStatus(t *testing.T) {
 	}
 }
 
+func TestErrorModel_WithStack(t *testing.T) {
+	errStack := typego.NewError("01", "general error").WithStack().GetStack()
+
+	if len(errStack) == 0 {
+		log.Fatal("`errStack` must not empty")
+	}
+
+	if !strings.Contains(errStack[0], "TestErrorModel_WithStack") {
+		log.Fatal("`errStack[0]` must contain `TestErrorModel_WithStack`")
+	}
+}
+
+func TestErrorModel_WithoutStack(t *testing.T) {
+	if errStack := typego.NewError("01", "general error").WithStack().WithoutStack().GetStack(); len(errStack) != 0 {
+		log.Fatal("`errStack` must empty")
+	}
+}
+
+func TestErrorModel_GetLevel(t *testing.T) {
+	if errLevel := typego.NewError("", "").GetLevel(); errLevel != "error" {
+		log.Fatal("`errLevel` must be `error`")
+	}
+}
+
 func TestErrorModel_GetProcessID(t *testing.T) {
 	if errProcessID := typego.NewError("", "").SetProcessID("123").GetProcessID(); errProcessID != "123" {
 		log.Fatal("`errProcessID` must be `123`")
@@ -102,6 +147,16 @@ func TestErrorModel_GetMessage(t *testing.T) {
 	}
 }
 
+func TestErrorModel_GetDeveloperMessage(t *testing.T) {
+	if errDeveloperMessage := typego.NewError("", "general error").GetDeveloperMessage(); errDeveloperMessage != "" {
+		log.Fatal("`errDeveloperMessage` must be empty")
+	}
+
+	if errDeveloperMessage := typego.NewError("", "general error").SetDeveloperMessage("database timeout").GetDeveloperMessage(); errDeveloperMessage != "database timeout" {
+		log.Fatal("`errDeveloperMessage` must be `database timeout`")
+	}
+}
+
 func TestErrorModel_GetInfo(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		if errInfos := typego.NewError("", "").AddInfo(errors.New("raw error"), errors.New("raw error 2")).AddInfo(errors.New("raw error 3")).GetInfo(); fmt.Sprintf("%v", errInfos) != fmt.Sprintf("%v", []string{"raw error", "raw error 2", "raw error 3"}) {
@@ -122,6 +177,45 @@ func TestErrorModel_GetInfo(t *testing.T) {
 	})
 }
 
+func TestErrorModel_GetField(t *testing.T) {
+	err := typego.NewError("", "").AddField("user_id", 1).AddField("reason", errors.New("raw error"))
+
+	if userID := err.GetField("user_id"); userID != 1 {
+		log.Fatal("`userID` must be `1`")
+	}
+
+	if reason := err.GetField("reason"); reason != "raw error" {
+		log.Fatal("`reason` must be `raw error`")
+	}
+
+	if amount := err.GetField("amount"); amount != nil {
+		log.Fatal("`amount` must nil")
+	}
+}
+
+func TestErrorModel_GetFields(t *testing.T) {
+	err := typego.NewError("", "").AddFields(map[string]any{"user_id": 1, "amount": 10.5})
+	errWithField := err.AddField("user_id", 2)
+
+	if fields := err.GetFields(); fmt.Sprintf("%v", fields) != fmt.Sprintf("%v", map[string]any{"amount": 10.5, "user_id": 1}) {
+		log.Fatal("`fields` must be `map[string]any{\"amount\": 10.5, \"user_id\": 1}`")
+	}
+
+	if fields := errWithField.GetFields(); fmt.Sprintf("%v", fields) != fmt.Sprintf("%v", map[string]any{"amount": 10.5, "user_id": 2}) {
+		log.Fatal("`fields` must be `map[string]any{\"amount\": 10.5, \"user_id\": 2}`")
+	}
+
+	err.GetFields()["user_id"] = 3
+
+	if userID := err.GetField("user_id"); userID != 1 {
+		log.Fatal("`userID` must be `1`")
+	}
+
+	if fields := typego.NewError("", "").GetFields(); fields != nil {
+		log.Fatal("`fields` must nil")
+	}
+}
+
 func TestErrorModel_GetDebug(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		if errDebugs := typego.NewError("", "").AddDebug(errors.New("raw error"), errors.New("raw error 2")).AddDebug(errors.New("raw error 3")).GetDebug(); fmt.Sprintf("%v", errDebugs) != fmt.Sprintf("%v", []string{"raw error", "raw error 2", "raw error 3"}) {
@@ -166,9 +260,134 @@ func TestErrorModel_Log(t *testing.T) {
 	_ = typego.NewError("01", "general error").Log()
 }
 
+func TestErrorModel_Fields(t *testing.T) {
+	if err := typego.NewError("01", "general error").AddField("user_id", 1).AddField("paid", true).AddField("ch", make(chan int)).Error(); !strings.HasPrefix(err, "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"fields\":{\"ch\":\"0x") || !strings.HasSuffix(err, "\",\"paid\":true,\"user_id\":1}}") {
+		log.Fatal("`err` must contain the fields with their original types")
+	}
+}
+
+func TestErrorModel_Unwrap(t *testing.T) {
+	cause := errors.New("raw error")
+
+	if errCause := typego.Wrap(cause, "01", "general error").Unwrap(); errCause != cause {
+		log.Fatal("`errCause` must be `cause`")
+	}
+
+	if errCause := typego.NewError("01", "general error").Unwrap(); errCause != nil {
+		log.Fatal("`errCause` must nil")
+	}
+}
+
+func TestErrorModel_Is(t *testing.T) {
+	errNotFound := typego.NewError("404", "not found")
+
+	if !errors.Is(typego.NewError("404", "user not found"), errNotFound) {
+		log.Fatal("`errors.Is` must be `true`")
+	}
+
+	if errors.Is(typego.NewError("500", "general error"), errNotFound) {
+		log.Fatal("`errors.Is` must be `false`")
+	}
+
+	if !errors.Is(typego.Wrap(typego.Wrap(io.EOF, "02", "read error"), "01", "general error"), io.EOF) {
+		log.Fatal("`errors.Is` must be `true`")
+	}
+
+	if !errors.Is(fmt.Errorf("context: %w", typego.Wrap(io.EOF, "02", "read error")), typego.NewError("02", "")) {
+		log.Fatal("`errors.Is` must be `true`")
+	}
+}
+
+func TestErrorModel_As(t *testing.T) {
+	var target typego.Error
+
+	if !errors.As(fmt.Errorf("context: %w", typego.NewError("01", "general error")), &target) {
+		log.Fatal("`errors.As` must be `true`")
+	}
+
+	if errCode := target.GetCode(); errCode != "01" {
+		log.Fatal("`errCode` must be `01`")
+	}
+
+	if typego.NewError("01", "general error").As(new(error)) {
+		log.Fatal("`As` must be `false`")
+	}
+}
+
+func TestErrorModel_GetStack(t *testing.T) {
+	if errStack := typego.NewError("01", "general error").GetStack(); errStack != nil {
+		log.Fatal("`errStack` must nil")
+	}
+}
+
+func TestErrorModel_Format(t *testing.T) {
+	err := typego.NewError("01", "general error")
+
+	if errString := fmt.Sprintf("%v", err); errString != err.Error() {
+		log.Fatal("`errString` must be equal to `err.Error()`")
+	}
+
+	if errString := fmt.Sprintf("%s", err); errString != err.Error() {
+		log.Fatal("`errString` must be equal to `err.Error()`")
+	}
+
+	errWithStack := err.WithStack()
+
+	if errString := fmt.Sprintf("%+v", errWithStack); !strings.HasPrefix(errString, errWithStack.WithoutStack().Error()+"\n\t") || !strings.Contains(errString, "TestErrorModel_Format") {
+		log.Fatal("`errString` must contain the error string followed by the stack trace")
+	}
+
+	if errString := fmt.Sprintf("%+v", errWithStack); strings.Contains(errString, "\"stack\"") || strings.Count(errString, "TestErrorModel_Format") != 1 {
+		log.Fatal("`errString` must contain the stack trace once")
+	}
+
+	if errString := fmt.Sprintf("%+v", err); errString != err.Error() {
+		log.Fatal("`errString` must be equal to `err.Error()`")
+	}
+}
+
 func TestErrorModel_Error(t *testing.T) {
-	if err := typego.NewError("01", "general error").SetHttpStatus(500).SetRPCStatus(13).AddInfo(errors.New("raw error").Error()).AddInfo("raw error 2").Error(); err != "{\"level\":\"error\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\",\"raw error 2\"],\"http_status\":500,\"rpc_status\":13}" {
-		log.Fatal("`err` must be `{\"level\":\"error\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\",\"raw error 2\"],\"http_status\":500,\"rpc_status\":13}`")
+	if err := typego.NewError("01", "general error").SetHttpStatus(500).SetRPCStatus(13).AddInfo(errors.New("raw error").Error()).AddInfo("raw error 2").Error(); err != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\",\"raw error 2\"],\"http_status\":500,\"rpc_status\":13}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\",\"raw error 2\"],\"http_status\":500,\"rpc_status\":13}`")
+	}
+}
+
+func TestErrorModel_PublicJSON(t *testing.T) {
+	err := typego.NewError("01", "general error").SetProcessID("123").SetDeveloperMessage("database timeout").AddInfo("raw error").AddField("user_id", 1).AddDebug("debug").SetHttpStatus(500).WithStack()
+
+	if errJSON := err.PublicJSON(); errJSON != "{\"process_id\":\"123\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\"]}" {
+		log.Fatal("`errJSON` must be `{\"process_id\":\"123\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\"]}`")
+	}
+}
+
+func TestErrorModel_InternalJSON(t *testing.T) {
+	err := typego.NewError("01", "general error").SetProcessID("123").SetDeveloperMessage("database timeout").AddInfo("raw error").AddField("user_id", 1).AddDebug("debug").SetHttpStatus(500)
+
+	if errJSON := err.InternalJSON(); errJSON != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"code\":\"01\",\"message\":\"general error\",\"developer_message\":\"database timeout\",\"info\":[\"raw error\"],\"fields\":{\"user_id\":1},\"http_status\":500,\"debug\":[\"debug\"]}" {
+		log.Fatal("`errJSON` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"code\":\"01\",\"message\":\"general error\",\"developer_message\":\"database timeout\",\"info\":[\"raw error\"],\"fields\":{\"user_id\":1},\"http_status\":500,\"debug\":[\"debug\"]}`")
+	}
+
+	if errJSON := err.InternalJSON(); errJSON != err.Error() {
+		log.Fatal("`errJSON` must be equal to `err.Error()`")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	var pathErr *fs.PathError
+
+	cause := &fs.PathError{Op: "open", Path: "/tmp/test", Err: fs.ErrNotExist}
+	err := typego.Wrap(cause, "01", "general error")
+
+	if errCode := err.GetCode(); errCode != "01" {
+		log.Fatal("`errCode` must be `01`")
+	}
+
+	if !errors.As(err, &pathErr) {
+		log.Fatal("`errors.As` must be `true`")
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal("`errors.Is` must be `true`")
 	}
 }
 
@@ -205,15 +424,32 @@ func TestNewErrorFromError(t *testing.T) {
 		}
 	})
 
+	t.Run("fields", func(t *testing.T) {
+		err := typego.NewErrorFromError(typego.NewError("01", "general error").AddFields(map[string]any{"user_id": 9007199254740993, "name": "test"}))
+
+		if userID := err.GetField("user_id"); fmt.Sprintf("%v", userID) != "9007199254740993" {
+			log.Fatal("`userID` must be `9007199254740993`")
+		}
+
+		if name := err.GetField("name"); name != "test" {
+			log.Fatal("`name` must be `test`")
+		}
+	})
+
 	t.Run("invalid_format", func(t *testing.T) {
-		err := typego.NewErrorFromError(errors.New("error: code=01"))
+		rawErr := errors.New("error: code=01")
+		err := typego.NewErrorFromError(rawErr)
+
+		if errLevel := err.GetLevel(); errLevel != typego.LevelError {
+			log.Fatal("`errLevel` must be `error`")
+		}
 
-		if errCode := err.GetCode(); errCode != "" {
-			log.Fatal("`errCode` must be ``")
+		if errCode := err.GetCode(); errCode != "UNKNOWN" {
+			log.Fatal("`errCode` must be `UNKNOWN`")
 		}
 
-		if errMessage := err.GetMessage(); errMessage != "" {
-			log.Fatal("`errMessage` must be ``")
+		if errMessage := err.GetMessage(); errMessage != "error: code=01" {
+			log.Fatal("`errMessage` must be `error: code=01`")
 		}
 
 		if errHttpStatus := err.GetHttpStatus(); errHttpStatus != 0 {
@@ -227,9 +463,75 @@ func TestNewErrorFromError(t *testing.T) {
 		if errInfoLen := len(err.GetInfo()); errInfoLen != 0 {
 			log.Fatal("`errInfoLen` must be `0`")
 		}
+
+		if !errors.Is(err, rawErr) {
+			log.Fatal("`err` must wrap `rawErr`")
+		}
+	})
+
+	t.Run("default_code", func(t *testing.T) {
+		typego.SetDefaultErrorCode("99")
+		defer typego.SetDefaultErrorCode("UNKNOWN")
+
+		if errCode := typego.NewErrorFromError(errors.New("boom")).GetCode(); errCode != "99" {
+			log.Fatal("`errCode` must be `99`")
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		err := typego.NewErrorFromError(fmt.Errorf("query user: %w", typego.NewError("01", "general error").AddField("user_id", 1)))
+
+		if errCode := err.GetCode(); errCode != "01" {
+			log.Fatal("`errCode` must be `01`")
+		}
+
+		if userID := err.GetField("user_id"); userID != 1 {
+			log.Fatal("`userID` must be `1`")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if err := typego.NewErrorFromError(nil); err != nil {
+			log.Fatal("`err` must nil")
+		}
 	})
 }
 
+func TestParseError(t *testing.T) {
+	if _, err := typego.ParseError(nil); !errors.Is(err, typego.ErrNilError) {
+		log.Fatal("`err` must be `ErrNilError`")
+	}
+
+	if _, err := typego.ParseError(errors.New("boom")); !errors.Is(err, typego.ErrInvalidFormat) {
+		log.Fatal("`err` must be `ErrInvalidFormat`")
+	}
+
+	if _, err := typego.ParseError(errors.New("{\"message\":\"general error\"}")); err == nil || err.Error() != "typego: invalid error format: missing code" {
+		log.Fatal("`err` must be `typego: invalid error format: missing code`")
+	}
+
+	if _, err := typego.ParseError(errors.New("{\"code\":\"01\"} {\"code\":\"02\"}")); !errors.Is(err, typego.ErrInvalidFormat) {
+		log.Fatal("`err` must be `ErrInvalidFormat`")
+	}
+
+	if _, err := typego.ParseError(errors.New("{\"code\":\"01\",\"http_status\":\"500\"}")); !errors.Is(err, typego.ErrInvalidFormat) {
+		log.Fatal("`err` must be `ErrInvalidFormat`")
+	}
+
+	err, er := typego.ParseError(errors.New("{\"code\":\"01\",\"message\":\"general error\"}"))
+	if er != nil {
+		log.Fatal("`er` must nil")
+	}
+
+	if errLevel := err.GetLevel(); errLevel != typego.LevelError {
+		log.Fatal("`errLevel` must be `error`")
+	}
+
+	if errCode := err.GetCode(); errCode != "01" {
+		log.Fatal("`errCode` must be `01`")
+	}
+}
+
 func TestErrorModel_AsGlobalVariable(t *testing.T) {
 	errMap := make(map[string]string)
 
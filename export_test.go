@@ -0,0 +1,51 @@
+package typego
+
+import "math/rand"
+
+// SetExit replaces the function used to exit the program when a fatal entry is logged
+func SetExit(fn func(code int)) {
+	exit = fn
+}
+
+// SetSample replaces the function used to sample the entries. A nil function resets it to the default
+func SetSample(fn func() float64) {
+	if fn == nil {
+		fn = rand.Float64
+	}
+
+	sample = fn
+}
+
+// DefaultErrorLog gets the default error log handler
+func DefaultErrorLog() ErrorLogHandler {
+	return defaultErrorLogHandler
+}
+
+// DefaultInfoLog gets the default info log handler
+func DefaultInfoLog() InfoLogHandler {
+	return defaultInfoLogHandler
+}
+
+// GetErrorLog gets the current error log handler
+func GetErrorLog() ErrorLogHandler {
+	return getErrorLogHandler()
+}
+
+// GetInfoLog gets the current info log handler of the level
+func GetInfoLog(level string) InfoLogHandler {
+	return getInfoLogHandler(level)
+}
+
+// LogBuckets gets the number of buckets of the log limit
+func LogBuckets() int {
+	logLimiter.Lock()
+	defer logLimiter.Unlock()
+
+	return len(logLimiter.buckets)
+}
+
+// AllowLog reports whether the entry of the level, code and process name is logged by the log limit
+func AllowLog(level string, code string, processName string) bool {
+	_, ok := allowLog(level, code, processName)
+	return ok
+}
@@ -0,0 +1,79 @@
+package typego
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// mergeFields returns a new map that contains the fields followed by the additional fields, so the original map
+// shared by other instances is never modified. The additional fields are redacted by the active redactor
+func mergeFields(fields map[string]any, additional map[string]any) map[string]any {
+	if len(additional) == 0 {
+		return fields
+	}
+
+	merged := make(map[string]any, len(fields)+len(additional))
+
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	r := activeRedactor()
+
+	for k, v := range additional {
+		if redacted, ok := r.RedactKey(k, v); ok {
+			if redacted != nil {
+				merged[k] = redacted
+			}
+
+			continue
+		}
+
+		merged[k] = fieldValue(r, v)
+	}
+
+	return merged
+}
+
+// fieldValue keeps the field value as is, so its type is preserved when serialized. An error is stored as its
+// message and a value that cannot be serialized to JSON is stored as its string representation. Strings and composite
+// values are redacted by the redactor
+func fieldValue(r *Redactor, value any) any {
+	switch v := value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return v
+	case string:
+		return r.RedactString(v)
+	case error:
+		return r.RedactString(v.Error())
+	}
+
+	original, err := json.Marshal(value)
+	if err != nil {
+		return r.RedactString(fmt.Sprintf("%+v", value))
+	}
+
+	redactedValue := r.Redact(value)
+
+	if redacted, err := json.Marshal(redactedValue); err == nil && bytes.Equal(original, redacted) {
+		return value
+	}
+
+	return redactedValue
+}
+
+// copyFields returns a copy of the fields
+func copyFields(fields map[string]any) map[string]any {
+	if fields == nil {
+		return nil
+	}
+
+	c := make(map[string]any, len(fields))
+
+	for k, v := range fields {
+		c[k] = v
+	}
+
+	return c
+}
@@ -1,52 +1,147 @@
 package typego
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
 
-// JSONStringCleaner cleans json string from double quotes (")
+// JSONStringMode decides how a JSON object or array string added by AddInfo or AddDebug is stored
+type JSONStringMode int
+
+const (
+	// JSONStringClean flattens the JSON string by JSONStringCleaner, such as `{code: 01, info: [a, b]}`
+	JSONStringClean JSONStringMode = iota
+
+	// JSONStringRaw keeps the JSON string as it is. A JSON object or array string is embedded in the JSON output of the
+	// entry as a raw nested JSON value instead of a JSON string
+	JSONStringRaw
+)
+
+var jsonStringMode atomic.Int64
+
+// SetJSONStringMode sets how the JSON object or array strings and error strings added by AddInfo and AddDebug are
+// stored. The default mode is JSONStringClean. The other strings are always stored as they are
+func SetJSONStringMode(mode JSONStringMode) {
+	jsonStringMode.Store(int64(mode))
+}
+
+// JSONStringCleaner flattens a JSON object or array string into a readable form without the quotes, such as
+// `{"code":"01","info":["a","b"]}` into `{code: 01, info: [a, b]}`. The string values are unescaped, and the numbers,
+// booleans and nulls are kept. Any other string, including an invalid JSON, is returned as it is
 func JSONStringCleaner(jsonString string) string {
-	var builder strings.Builder
-
-	length := len(jsonString)
-	i := 0
-
-	for i < length {
-		if i+4 <= length {
-			switch jsonString[i : i+4] {
-			case "\"],\"":
-				builder.WriteString("], ")
-				i += 4
-				continue
-			case "\":[\"":
-				builder.WriteString(": [")
-				i += 4
-				continue
-			case "\",\"":
-				builder.WriteString(", ")
-				i += 4
-				continue
-			case "\":\"":
-				builder.WriteString(": ")
-				i += 4
-				continue
-			}
+	trimmed := strings.TrimSpace(jsonString)
+
+	if !isJSONContainer(trimmed) {
+		return jsonString
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+
+	// containers holds the open objects and arrays with the number of tokens written in them, so a key is followed by
+	// `: ` and the other tokens are separated by `, `
+	type container struct {
+		object bool
+		tokens int
+	}
+
+	var (
+		builder    strings.Builder
+		containers []container
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
 		}
 
-		if i+3 <= length {
-			switch jsonString[i : i+3] {
-			case "{\"\"":
-				builder.WriteString("{")
-				i += 3
-				continue
-			case ",\"":
+		if err != nil {
+			return jsonString
+		}
+
+		delim, isDelim := token.(json.Delim)
+
+		if n := len(containers); n > 0 && (!isDelim || delim == '{' || delim == '[') {
+			c := &containers[n-1]
+
+			switch {
+			case c.object && c.tokens%2 == 1:
+				builder.WriteString(": ")
+			case c.tokens > 0:
 				builder.WriteString(", ")
-				i += 2
-				continue
 			}
+
+			c.tokens++
 		}
 
-		builder.WriteByte(jsonString[i])
-		i++
+		switch v := token.(type) {
+		case json.Delim:
+			builder.WriteByte(byte(v))
+
+			if v == '{' || v == '[' {
+				containers = append(containers, container{object: v == '{'})
+			} else {
+				containers = containers[:len(containers)-1]
+			}
+		case string:
+			builder.WriteString(v)
+		case json.Number:
+			builder.WriteString(v.String())
+		case bool:
+			builder.WriteString(strconv.FormatBool(v))
+		case nil:
+			builder.WriteString("null")
+		}
 	}
 
 	return builder.String()
 }
+
+// isJSONContainer reports whether the string is a valid JSON object or array without surrounding spaces
+func isJSONContainer(s string) bool {
+	return len(s) >= 2 && (s[0] == '{' || s[0] == '[') && json.Valid([]byte(s))
+}
+
+// cleanJSONString stores the JSON string according to the mode
+func cleanJSONString(mode JSONStringMode, s string) string {
+	if mode == JSONStringRaw {
+		return s
+	}
+
+	return JSONStringCleaner(s)
+}
+
+// stringifyValue converts the value to a string after redacting it by the redactor. A string or an error is stored
+// according to the JSON string mode, and any other value is serialized to JSON
+func stringifyValue(r *Redactor, mode JSONStringMode, value any) string {
+	switch v := value.(type) {
+	case string:
+		return cleanJSONString(mode, r.RedactString(v))
+	case error:
+		return cleanJSONString(mode, r.RedactString(v.Error()))
+	}
+
+	jsonValue, err := json.Marshal(r.Redact(value))
+	if err != nil {
+		return r.RedactString(fmt.Sprintf("%+v", value))
+	}
+
+	return string(jsonValue)
+}
+
+// copyStrings copies the values. It keeps nil values nil, so they are still encoded as `null`
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	c := make([]string, len(values))
+	copy(c, values)
+
+	return c
+}
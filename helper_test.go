@@ -0,0 +1,126 @@
+package typego_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestJSONStringCleaner(t *testing.T) {
+	tests := map[string]string{
+		`{"code":"01","info":["a","b"],"status":500,"ok":true,"cause":null}`: `{code: 01, info: [a, b], status: 500, ok: true, cause: null}`,
+		` ["a", {"b": []}, {}] `: `[a, {b: []}, {}]`,
+		`{"message":"say \"hi\", ok","url":"https://example.com/?q=\"1\""}`: `{message: say "hi", ok, url: https://example.com/?q="1"}`,
+		`{"amount":1.50e3}`:          `{amount: 1.50e3}`,
+		`a","b`:                      `a","b`,
+		`a":"b`:                      `a":"b`,
+		`https://example.com/?q="1"`: `https://example.com/?q="1"`,
+		`{"unterminated":["a","b"]`:  `{"unterminated":["a","b"]`,
+		`{"a":"b"} {"c":"d"}`:        `{"a":"b"} {"c":"d"}`,
+		`"quoted"`:                   `"quoted"`,
+		``:                           ``,
+	}
+
+	for s, expected := range tests {
+		if cleaned := typego.JSONStringCleaner(s); cleaned != expected {
+			log.Fatal("`cleaned` must be `" + expected + "`, got `" + cleaned + "`")
+		}
+	}
+}
+
+func TestSetJSONStringMode(t *testing.T) {
+	defer typego.SetJSONStringMode(typego.JSONStringClean)
+
+	cause := errors.New(`{"code":"01","info":["a","b"]}`)
+
+	if errInfo := typego.NewError("02", "").AddInfo(cause, `a","b`).GetInfo(); strings.Join(errInfo, "|") != `{code: 01, info: [a, b]}|a","b` {
+		log.Fatal("`errInfo` must be `{code: 01, info: [a, b]}|a\",\"b`")
+	}
+
+	typego.SetJSONStringMode(typego.JSONStringRaw)
+
+	if errInfo := typego.NewError("02", "").AddInfo(cause).GetInfo(); errInfo[0] != `{"code":"01","info":["a","b"]}` {
+		log.Fatal("`errInfo` must be `{\"code\":\"01\",\"info\":[\"a\",\"b\"]}`")
+	}
+}
+
+func TestSetJSONStringMode_rawEntry(t *testing.T) {
+	defer typego.SetJSONStringMode(typego.JSONStringClean)
+
+	typego.SetJSONStringMode(typego.JSONStringRaw)
+
+	err := typego.NewError("01", "").AddInfo(`{"a": 1}`, `[1,2`, "text").AddDebug(errors.New(`["b"]`))
+	info := typego.NewInfo().AddInfo(`{"a":1}`)
+
+	typego.SetJSONStringMode(typego.JSONStringClean)
+
+	expected := `{"level":"error","timestamp":"2024-01-01T00:00:00Z","code":"01","message":"","info":[{"a":1},"[1,2","text"],"debug":[["b"]]}`
+
+	if errString := err.Error(); errString != expected {
+		log.Fatal("`errString` must be `" + expected + "`, got `" + errString + "`")
+	}
+
+	if errPublic := err.PublicJSON(); errPublic != `{"code":"01","message":"","info":[{"a":1},"[1,2","text"]}` {
+		log.Fatal("`errPublic` must be `{\"code\":\"01\",\"message\":\"\",\"info\":[{\"a\":1},\"[1,2\",\"text\"]}`, got `" + errPublic + "`")
+	}
+
+	parsed, er := typego.ParseError(errors.New(err.Error()))
+	if er != nil {
+		log.Fatal(er)
+	}
+
+	if parsedInfo := strings.Join(parsed.GetInfo(), "|"); parsedInfo != `{"a":1}|[1,2|text` {
+		log.Fatal("`parsedInfo` must be `{\"a\":1}|[1,2|text`, got `" + parsedInfo + "`")
+	}
+
+	if parsedString := parsed.AddInfo(`{"c":2}`).Error(); parsedString != strings.Replace(expected, `"text"]`, `"text","{c: 2}"]`, 1) {
+		log.Fatal("`parsedString` must keep the raw json, got `" + parsedString + "`")
+	}
+
+	if infoString := info.String(); infoString != `{"level":"info","timestamp":"2024-01-01T00:00:00Z","info":[{"a":1}]}` {
+		log.Fatal("`infoString` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":[{\"a\":1}]}`, got `" + infoString + "`")
+	}
+}
+
+func FuzzJSONStringCleaner(f *testing.F) {
+	f.Add(`{"a":"b"}`, "a", "b")
+	f.Add(`a","b`, `say "hi", ok`, `\`)
+	f.Add(`["a",`, "", "{}")
+
+	f.Fuzz(func(t *testing.T, s string, a string, b string) {
+		trimmed := strings.TrimSpace(s)
+
+		if len(trimmed) < 2 || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid([]byte(trimmed)) {
+			if cleaned := typego.JSONStringCleaner(s); cleaned != s {
+				t.Fatalf("`cleaned` must be `%s`, got `%s`", s, cleaned)
+			}
+		} else {
+			_ = typego.JSONStringCleaner(s)
+		}
+
+		array, err := json.Marshal([]string{a, b})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// json.Marshal replaces each invalid UTF-8 byte with U+FFFD, like the conversion to runes does
+		a = string([]rune(a))
+		b = string([]rune(b))
+
+		if cleaned := typego.JSONStringCleaner(string(array)); cleaned != "["+a+", "+b+"]" {
+			t.Fatalf("`cleaned` must be `[%s, %s]`, got `%s`", a, b, cleaned)
+		}
+
+		object, err := json.Marshal(map[string]string{a: b})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cleaned := typego.JSONStringCleaner(string(object)); cleaned != "{"+a+": "+b+"}" {
+			t.Fatalf("`cleaned` must be `{%s: %s}`, got `%s`", a, b, cleaned)
+		}
+	})
+}
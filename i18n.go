@@ -0,0 +1,210 @@
+package typego
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+)
+
+const defaultLocale = "en"
+
+var defaultLocaleValue = struct {
+	sync.RWMutex
+	value string
+}{
+	value: defaultLocale,
+}
+
+var defaultBundle atomic.Pointer[Bundle]
+
+func init() {
+	defaultBundle.Store(NewBundle())
+}
+
+// Bundle stores the localized error messages keyed by locale and error code. The messages are text/template templates
+// executed with the error fields as data, for example: `Saldo {{.account}} tidak cukup`
+type Bundle struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]*template.Template
+}
+
+// NewBundle generates new typego.Bundle without messages
+func NewBundle() *Bundle {
+	return &Bundle{
+		messages: make(map[string]map[string]*template.Template),
+	}
+}
+
+// DefaultBundle gets the bundle used by typego.Error.Localize()
+func DefaultBundle() *Bundle {
+	return defaultBundle.Load()
+}
+
+// SetDefaultBundle sets the bundle used by typego.Error.Localize()
+func SetDefaultBundle(b *Bundle) {
+	defaultBundle.Store(b)
+}
+
+// SetDefaultLocale sets the last locale of the fallback chain. The default locale is `en`
+func SetDefaultLocale(locale string) {
+	defaultLocaleValue.Lock()
+	defer defaultLocaleValue.Unlock()
+
+	defaultLocaleValue.value = normalizeLocale(locale)
+}
+
+// GetDefaultLocale gets the last locale of the fallback chain
+func GetDefaultLocale() string {
+	defaultLocaleValue.RLock()
+	defer defaultLocaleValue.RUnlock()
+
+	return defaultLocaleValue.value
+}
+
+// Add adds the messages of the locale keyed by error code. The existing messages of the same codes are replaced. None
+// of the messages is added if one of them is not a valid template. A message referring to a field the data does not
+// have is not executed, so the message is reported as missing
+func (b *Bundle) Add(locale string, messages map[string]string) error {
+	locale = normalizeLocale(locale)
+	templates := make(map[string]*template.Template, len(messages))
+
+	for code, message := range messages {
+		tmpl, err := template.New(code).Option("missingkey=error").Parse(message)
+		if err != nil {
+			return fmt.Errorf("typego: parse message %s of locale %s: %w", code, locale, err)
+		}
+
+		templates[code] = tmpl
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.messages[locale] == nil {
+		b.messages[locale] = make(map[string]*template.Template, len(templates))
+	}
+
+	for code, tmpl := range templates {
+		b.messages[locale][code] = tmpl
+	}
+
+	return nil
+}
+
+// LoadJSON adds the messages of the locale from a JSON object keyed by error code, for example:
+// `{"01":"Terjadi kesalahan"}`
+func (b *Bundle) LoadJSON(locale string, data []byte) error {
+	var messages map[string]string
+
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("typego: load messages of locale %s: %w", locale, err)
+	}
+
+	return b.Add(locale, messages)
+}
+
+// LoadFS adds the messages from the JSON files of the file system matching the pattern, for example: an embed.FS with
+// the pattern `locales/*.json`. The locale is taken from the file name, so `locales/id-ID.json` contains the messages
+// of the `id-ID` locale
+func (b *Bundle) LoadFS(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		locale := strings.TrimSuffix(path.Base(name), path.Ext(name))
+
+		if err = b.LoadJSON(locale, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Message gets the message of the error code in the locale executed with the data. It follows the fallback chain of
+// the locale, for example: `id-ID` → `id` → the default locale. It reports whether the message exists and the data has
+// every field the message refers to
+func (b *Bundle) Message(locale string, code string, data any) (string, bool) {
+	b.mu.RLock()
+
+	var tmpl *template.Template
+
+	for _, l := range localeChain(locale) {
+		if t, ok := b.messages[l][code]; ok {
+			tmpl = t
+			break
+		}
+	}
+
+	b.mu.RUnlock()
+
+	if tmpl == nil {
+		return "", false
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", false
+	}
+
+	return buf.String(), true
+}
+
+// Locales returns the sorted locales that have messages
+func (b *Bundle) Locales() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	locales := make([]string, 0, len(b.messages))
+
+	for locale := range b.messages {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+// localeChain returns the fallback chain of the locale, from the most specific locale to the default locale
+func localeChain(locale string) []string {
+	locale = normalizeLocale(locale)
+	chain := make([]string, 0, 3)
+
+	for locale != "" {
+		chain = append(chain, locale)
+
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+
+		locale = locale[:i]
+	}
+
+	if dl := GetDefaultLocale(); dl != "" && (len(chain) == 0 || chain[len(chain)-1] != dl) {
+		chain = append(chain, dl)
+	}
+
+	return chain
+}
+
+// normalizeLocale lower cases the locale and uses `-` as the separator, so `id_ID` and `id-id` are the same locale
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
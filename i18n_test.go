@@ -0,0 +1,178 @@
+package typego_test
+
+import (
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewBundle(t *testing.T) {
+	if b := typego.NewBundle(); b == nil {
+		log.Fatal("`b` must not nil")
+	}
+}
+
+func TestSetDefaultBundle(t *testing.T) {
+	defer typego.SetDefaultBundle(typego.DefaultBundle())
+
+	b := typego.NewBundle()
+
+	typego.SetDefaultBundle(b)
+
+	if typego.DefaultBundle() != b {
+		log.Fatal("`DefaultBundle` must be `b`")
+	}
+}
+
+func TestSetDefaultLocale(t *testing.T) {
+	defer typego.SetDefaultLocale("en")
+
+	if locale := typego.GetDefaultLocale(); locale != "en" {
+		log.Fatal("`locale` must be `en`")
+	}
+
+	typego.SetDefaultLocale("id_ID")
+
+	if locale := typego.GetDefaultLocale(); locale != "id-id" {
+		log.Fatal("`locale` must be `id-id`")
+	}
+}
+
+func TestBundle_Add(t *testing.T) {
+	b := typego.NewBundle()
+
+	if err := b.Add("id", map[string]string{"01": "Terjadi kesalahan"}); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if err := b.Add("id", map[string]string{"02": "Saldo {{.account}"}); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+
+	if _, ok := b.Message("id", "02", nil); ok {
+		log.Fatal("`02` must not be added")
+	}
+}
+
+func TestBundle_LoadJSON(t *testing.T) {
+	b := typego.NewBundle()
+
+	if err := b.LoadJSON("id", []byte("{\"01\":\"Terjadi kesalahan\"}")); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if message, _ := b.Message("id", "01", nil); message != "Terjadi kesalahan" {
+		log.Fatal("`message` must be `Terjadi kesalahan`")
+	}
+
+	if err := b.LoadJSON("id", []byte("[]")); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestBundle_LoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":    {Data: []byte("{\"01\":\"General error\"}")},
+		"locales/id.json":    {Data: []byte("{\"01\":\"Terjadi kesalahan\"}")},
+		"locales/id-ID.json": {Data: []byte("{\"02\":\"Saldo {{.account}} tidak cukup\"}")},
+		"locales/readme.txt": {Data: []byte("not a locale")},
+	}
+
+	b := typego.NewBundle()
+
+	if err := b.LoadFS(fsys, "locales/*.json"); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if locales := b.Locales(); len(locales) != 3 || locales[0] != "en" || locales[1] != "id" || locales[2] != "id-id" {
+		log.Fatal("`locales` must be `[en id id-id]`")
+	}
+
+	if err := b.LoadFS(fstest.MapFS{"locales/id.json": {Data: []byte("{")}}, "locales/*.json"); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+
+	if err := b.LoadFS(fsys, "["); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestBundle_Message(t *testing.T) {
+	b := typego.NewBundle()
+
+	_ = b.Add("en", map[string]string{"01": "General error", "03": "Not found"})
+	_ = b.Add("id", map[string]string{"01": "Terjadi kesalahan"})
+	_ = b.Add("id-ID", map[string]string{"02": "Saldo {{.account}} tidak cukup"})
+
+	if message, _ := b.Message("id-ID", "02", map[string]any{"account": "123"}); message != "Saldo 123 tidak cukup" {
+		log.Fatal("`message` must be `Saldo 123 tidak cukup`")
+	}
+
+	if message, _ := b.Message("id_ID", "01", nil); message != "Terjadi kesalahan" {
+		log.Fatal("`message` must be `Terjadi kesalahan`")
+	}
+
+	if message, _ := b.Message("id-ID", "03", nil); message != "Not found" {
+		log.Fatal("`message` must be `Not found`")
+	}
+
+	if _, ok := b.Message("id-ID", "04", nil); ok {
+		log.Fatal("`ok` must be false")
+	}
+
+	if _, ok := b.Message("id", "02", nil); ok {
+		log.Fatal("`ok` must be false")
+	}
+
+	if _, ok := b.Message("id-ID", "02", map[string]any{"user": "123"}); ok {
+		log.Fatal("`ok` must be false")
+	}
+
+	if _, ok := b.Message("id-ID", "02", nil); ok {
+		log.Fatal("`ok` must be false")
+	}
+}
+
+func TestErrorModel_Localize(t *testing.T) {
+	defer typego.SetDefaultBundle(typego.DefaultBundle())
+
+	b := typego.NewBundle()
+
+	_ = b.Add("id", map[string]string{"01": "Saldo {{.account}} tidak cukup"})
+
+	typego.SetDefaultBundle(b)
+
+	err := typego.NewError("01", "insufficient balance").AddField("account", "123")
+
+	if message := err.Localize("id-ID").GetMessage(); message != "Saldo 123 tidak cukup" {
+		log.Fatal("`message` must be `Saldo 123 tidak cukup`")
+	}
+
+	if message := err.GetMessage(); message != "insufficient balance" {
+		log.Fatal("`message` must be `insufficient balance`")
+	}
+
+	if message := err.Localize("fr").GetMessage(); message != "insufficient balance" {
+		log.Fatal("`message` must be `insufficient balance`")
+	}
+
+	if localized := err.Localize("id"); !errors.Is(localized, err) {
+		log.Fatal("`localized` must keep the error code")
+	}
+}
+
+func TestErrorModel_Localize_missingField(t *testing.T) {
+	defer typego.SetDefaultBundle(typego.DefaultBundle())
+
+	b := typego.NewBundle()
+
+	_ = b.Add("en", map[string]string{"BAL": "Balance {{.account}} too low"})
+
+	typego.SetDefaultBundle(b)
+
+	if message := typego.NewError("BAL", "x").Localize("id-ID").GetMessage(); message != "x" {
+		log.Fatal("`message` must be `x`")
+	}
+}
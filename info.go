@@ -1,15 +1,25 @@
 package typego
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
+	"time"
 )
 
 type Info interface {
-	// AddInfo adds information and returns its instance
+	// AddInfo adds information and returns its instance. The values are stringified with the redactor and the JSON
+	// string mode active when they are added. The stringification of the scalar values is deferred until the
+	// information is first read, encoded or logged
 	AddInfo(info ...interface{}) Info
 
-	// AddDebug adds information debug and returns its instance
+	// AddField adds a structured field and returns its instance. The field value keeps its type when serialized
+	AddField(key string, value interface{}) Info
+
+	// AddFields adds structured fields and returns its instance. The field values keep their types when serialized
+	AddFields(fields map[string]interface{}) Info
+
+	// AddDebug adds information debug and returns its instance. The values are stringified like AddInfo
 	AddDebug(debug ...interface{}) Info
 
 	// SetProcessID sets process id
@@ -18,89 +28,184 @@ type Info interface {
 	// SetProcessName sets process name
 	SetProcessName(processName string) Info
 
+	// Start restarts the duration measurement from the current time and returns its instance. The duration is
+	// measured from the creation time if Start is not called
+	Start() Info
+
+	// Finish sets the duration since the start and returns its instance
+	Finish() Info
+
+	// GetLevel gets information level
+	GetLevel() string
+
+	// GetTimestamp gets the creation time
+	GetTimestamp() time.Time
+
+	// GetDuration gets the duration set by Finish
+	GetDuration() time.Duration
+
+	// GetSuppressed gets the number of entries suppressed by the log limit before the information was logged
+	GetSuppressed() uint64
+
+	// Data gets a copy of the information data
+	Data() InfoData
+
 	// GetProcessID gets process id
 	GetProcessID() string
 
 	// GetProcessName gets process name
 	GetProcessName() string
 
+	// GetField gets the structured field value of the key, or nil if the field does not exist
+	GetField(key string) interface{}
+
+	// GetFields gets a copy of the structured fields
+	GetFields() map[string]interface{}
+
 	// GetInfo gets information
 	GetInfo() []string
 
 	// GetDebug gets information debug
 	GetDebug() []string
 
-	// Log logs the information and return its instance
+	// Log logs the information and return its instance. A fatal entry exits the program after it is logged
 	Log() Info
 
-	// String returns the information in string
+	// LogCtx logs the information with the process id, process name and fields carried by the context, and return
+	// its instance. A fatal entry exits the program after it is logged
+	LogCtx(ctx context.Context) Info
+
+	// String returns the information encoded by the global encoder
 	String() string
 }
 
+// InfoData is the data of typego.Info. It can be embedded, encoded and decoded, and converted back to typego.Info by
+// InfoFromData. Decode it with json.Decoder.UseNumber to keep the precision of the numbers in the fields
+type InfoData struct {
+	Level       string                 `json:"level"`
+	Timestamp   Timestamp              `json:"timestamp,omitempty"`
+	ProcessID   string                 `json:"process_id,omitempty"`
+	ProcessName string                 `json:"process_name,omitempty"`
+	Info        []string               `json:"info"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	Debug       []string               `json:"debug,omitempty"`
+	DurationMS  float64                `json:"duration_ms,omitempty"`
+	Suppressed  uint64                 `json:"suppressed,omitempty"`
+}
+
 type infoModel struct {
-	Level       string   `json:"level"`
-	ProcessID   string   `json:"process_id,omitempty"`
-	ProcessName string   `json:"process_name,omitempty"`
-	Info        []string `json:"info"`
-	Debug       []string `json:"debug,omitempty"`
+	InfoData
+	started Timestamp
+	info    *lazyStrings
+	debug   *lazyStrings
+}
+
+// MarshalJSON encodes the information in the typego.Info JSON format
+func (i infoModel) MarshalJSON() ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	d := i.data()
+
+	b, err := appendInfoJSON(*buf, &d, i.rawStrings())
+	*buf = b
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), b...), nil
+}
+
+// UnmarshalJSON decodes the information from the typego.Info JSON format. The numbers in the fields are decoded as
+// json.Number, so they keep their precision. A raw JSON value in the information or debug is decoded as its JSON
+// string, and it is embedded as raw JSON again when the information is encoded
+func (i *infoModel) UnmarshalJSON(b []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	d := struct {
+		*InfoData
+		Info  jsonStrings `json:"info"`
+		Debug jsonStrings `json:"debug"`
+	}{
+		InfoData: &i.InfoData,
+	}
+
+	if err := decoder.Decode(&d); err != nil {
+		return err
+	}
+
+	i.Info, i.info = d.Info.lazy()
+	i.Debug, i.debug = d.Debug.lazy()
+
+	return nil
 }
 
 func (i infoModel) AddInfo(info ...interface{}) Info {
-	additionalInfo := make([]string, 0, len(info))
-
-	for _, j := range info {
-		switch v := j.(type) {
-		case string:
-			additionalInfo = append(additionalInfo, JSONStringCleaner(v))
-		case error:
-			additionalInfo = append(additionalInfo, JSONStringCleaner(v.Error()))
-		default:
-			jsonValue, err := json.Marshal(v)
-			if err != nil {
-				additionalInfo = append(additionalInfo, fmt.Sprintf("%+v", v))
-			} else {
-				additionalInfo = append(additionalInfo, string(jsonValue))
-			}
-		}
+	if len(info) > 0 {
+		i.Info, i.info = addStrings(i.info, i.Info, info)
 	}
 
-	i.Info = append(i.Info, additionalInfo...)
+	return &i
+}
+
+func (i infoModel) AddField(key string, value interface{}) Info {
+	i.Fields = mergeFields(i.Fields, map[string]interface{}{key: value})
+	return &i
+}
 
-	return i
+func (i infoModel) AddFields(fields map[string]interface{}) Info {
+	i.Fields = mergeFields(i.Fields, fields)
+	return &i
 }
 
 func (i infoModel) AddDebug(debug ...interface{}) Info {
-	additionalDebug := make([]string, 0, len(debug))
-
-	for _, j := range debug {
-		switch v := j.(type) {
-		case string:
-			additionalDebug = append(additionalDebug, JSONStringCleaner(v))
-		case error:
-			additionalDebug = append(additionalDebug, JSONStringCleaner(v.Error()))
-		default:
-			jsonValue, err := json.Marshal(v)
-			if err != nil {
-				additionalDebug = append(additionalDebug, fmt.Sprintf("%+v", v))
-			} else {
-				additionalDebug = append(additionalDebug, string(jsonValue))
-			}
-		}
+	if len(debug) > 0 {
+		i.Debug, i.debug = addStrings(i.debug, i.Debug, debug)
 	}
 
-	i.Debug = append(i.Debug, additionalDebug...)
-
-	return i
+	return &i
 }
 
 func (i infoModel) SetProcessID(processID string) Info {
 	i.ProcessID = processID
-	return i
+	return &i
 }
 
 func (i infoModel) SetProcessName(processName string) Info {
 	i.ProcessName = processName
-	return i
+	return &i
+}
+
+func (i infoModel) Start() Info {
+	i.started = NewTimestamp(now())
+	return &i
+}
+
+func (i infoModel) Finish() Info {
+	started := i.started
+	if started == 0 {
+		started = i.Timestamp
+	}
+
+	if started != 0 {
+		i.DurationMS = float64(now().Sub(started.Time())) / float64(time.Millisecond)
+	}
+
+	return &i
+}
+
+func (i infoModel) GetLevel() string {
+	return i.Level
+}
+
+func (i infoModel) GetTimestamp() time.Time {
+	return i.Timestamp.Time()
+}
+
+func (i infoModel) GetDuration() time.Duration {
+	return time.Duration(i.DurationMS * float64(time.Millisecond))
 }
 
 func (i infoModel) GetProcessID() string {
@@ -111,21 +216,71 @@ func (i infoModel) GetProcessName() string {
 	return i.ProcessName
 }
 
+func (i infoModel) GetField(key string) interface{} {
+	return i.Fields[key]
+}
+
+func (i infoModel) GetFields() map[string]interface{} {
+	return copyFields(i.Fields)
+}
+
 func (i infoModel) GetInfo() []string {
+	if i.info != nil {
+		return i.info.resolve()
+	}
+
 	return i.Info
 }
 
 func (i infoModel) GetDebug() []string {
+	if i.debug != nil {
+		return i.debug.resolve()
+	}
+
 	return i.Debug
 }
 
-func (i infoModel) Log() Info {
-	infoLogHandler(i)
-	return i
+func (i infoModel) GetSuppressed() uint64 {
+	return i.Suppressed
 }
 
-func (i infoModel) String() string {
-	b, err := json.Marshal(i)
+func (i infoModel) Data() InfoData {
+	d := i.data()
+	d.Info = copyStrings(d.Info)
+	d.Fields = copyFields(d.Fields)
+	d.Debug = copyStrings(d.Debug)
+
+	return d
+}
+
+// data gets the information data with the lazy information stringified. The data shares its slices and fields with
+// the information
+func (i infoModel) data() InfoData {
+	d := i.InfoData
+	d.Info = i.GetInfo()
+	d.Debug = i.GetDebug()
+
+	return d
+}
+
+// rawStrings gets the marks of the information and debug strings embedded as raw JSON
+func (i infoModel) rawStrings() rawStrings {
+	return rawStrings{
+		info:  i.info.rawMarks(),
+		debug: i.debug.rawMarks(),
+	}
+}
+
+// jsonString encodes the information in the typego.Info JSON format, or returns the encoding error string
+func (i infoModel) jsonString() string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	d := i.data()
+
+	b, err := appendInfoJSON(*buf, &d, i.rawStrings())
+	*buf = b
+
 	if err != nil {
 		return err.Error()
 	}
@@ -133,9 +288,66 @@ func (i infoModel) String() string {
 	return string(b)
 }
 
+func (i infoModel) Log() Info {
+	logInfo(i)
+	return &i
+}
+
+func (i infoModel) LogCtx(ctx context.Context) Info {
+	logInfo(i.fromContext(ctx))
+	return &i
+}
+
+func (i infoModel) String() string {
+	return encodeEntry(i)
+}
+
 // NewInfo generates new typego.Info
 func NewInfo() Info {
+	return newInfoModel(LevelInfo)
+}
+
+// NewDebugEntry generates new typego.Info with debug level
+func NewDebugEntry() Info {
+	return newInfoModel(LevelDebug)
+}
+
+// NewNotice generates new typego.Info with notice level
+func NewNotice() Info {
+	return newInfoModel(LevelNotice)
+}
+
+// NewWarning generates new typego.Info with warning level
+func NewWarning() Info {
+	return newInfoModel(LevelWarning)
+}
+
+// NewFatal generates new typego.Info with fatal level. Its Log() method flushes the registered flush hooks and exits
+// the program with the fatal exit code after the entry is logged
+func NewFatal() Info {
+	return newInfoModel(LevelFatal)
+}
+
+// newInfoModel generates new information model with the level and the current timestamp
+func newInfoModel(level string) *infoModel {
 	return &infoModel{
-		Level: "info",
+		InfoData: InfoData{
+			Level:     level,
+			Timestamp: NewTimestamp(now()),
+		},
 	}
 }
+
+// InfoFromData generates new typego.Info from a copy of the information data. The level defaults to `info` when it is
+// empty
+func InfoFromData(data InfoData) Info {
+	i := infoModel{
+		InfoData: infoModel{InfoData: data}.Data(),
+	}
+
+	if i.Level == "" {
+		i.Level = LevelInfo
+	}
+
+	return &i
+}
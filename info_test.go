@@ -20,6 +20,18 @@ func TestInfoModel_AddInfo(t *testing.T) {
 	}
 }
 
+func TestInfoModel_AddField(t *testing.T) {
+	if info := typego.NewInfo().AddField("user_id", 1); info == nil {
+		log.Fatal("`info` must not nil")
+	}
+}
+
+func TestInfoModel_AddFields(t *testing.T) {
+	if info := typego.NewInfo().AddFields(map[string]interface{}{"user_id": 1}); info == nil {
+		log.Fatal("`info` must not nil")
+	}
+}
+
 func TestInfoModel_AddDebug(t *testing.T) {
 	if info := typego.NewInfo().AddDebug(errors.New("raw info")); info == nil {
 		log.Fatal("`info` must not nil")
@@ -38,6 +50,12 @@ func TestInfoModel_SetProcessName(t *testing.T) {
 	}
 }
 
+func TestInfoModel_GetLevel(t *testing.T) {
+	if infoLevel := typego.NewInfo().GetLevel(); infoLevel != "info" {
+		log.Fatal("`infoLevel` must be `info`")
+	}
+}
+
 func TestInfoModel_GetProcessID(t *testing.T) {
 	if infoProcessID := typego.NewInfo().SetProcessID("123").GetProcessID(); infoProcessID != "123" {
 		log.Fatal("`infoProcessID` must be `123`")
@@ -70,6 +88,18 @@ func TestInfoModel_GetInfo(t *testing.T) {
 	})
 }
 
+func TestInfoModel_GetField(t *testing.T) {
+	if userID := typego.NewInfo().AddField("user_id", 1).GetField("user_id"); userID != 1 {
+		log.Fatal("`userID` must be `1`")
+	}
+}
+
+func TestInfoModel_GetFields(t *testing.T) {
+	if fields := typego.NewInfo().AddFields(map[string]interface{}{"user_id": 1}).AddField("amount", 10.5).GetFields(); fmt.Sprintf("%v", fields) != fmt.Sprintf("%v", map[string]interface{}{"amount": 10.5, "user_id": 1}) {
+		log.Fatal("`fields` must be `map[string]interface{}{\"amount\": 10.5, \"user_id\": 1}`")
+	}
+}
+
 func TestInfoModel_GetDebug(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		if infoDebugs := typego.NewInfo().AddDebug(errors.New("raw info"), errors.New("raw info 2")).AddDebug(errors.New("raw info 3")).GetDebug(); fmt.Sprintf("%v", infoDebugs) != fmt.Sprintf("%v", []string{"raw info", "raw info 2", "raw info 3"}) {
@@ -95,7 +125,11 @@ func TestInfoModel_Log(t *testing.T) {
 }
 
 func TestInfoModel_String(t *testing.T) {
-	if info := typego.NewInfo().String(); info != "{\"level\":\"info\",\"info\":null}" {
-		log.Fatal("`info` must be `{\"level\":\"info\",\"info\":null}`")
+	if info := typego.NewInfo().String(); info != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null}" {
+		log.Fatal("`info` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null}`")
+	}
+
+	if info := typego.NewInfo().AddField("user_id", 1).String(); info != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null,\"fields\":{\"user_id\":1}}" {
+		log.Fatal("`info` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null,\"fields\":{\"user_id\":1}}`")
 	}
 }
@@ -0,0 +1,409 @@
+package typego
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxPooledBufferSize is the capacity above which a buffer is not returned to the pool, so a single large entry does
+// not keep its memory alive
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1024)
+		return &b
+	},
+}
+
+// getBuffer gets an empty buffer from the pool
+func getBuffer() *[]byte {
+	b := bufferPool.Get().(*[]byte)
+	*b = (*b)[:0]
+
+	return b
+}
+
+// putBuffer returns the buffer to the pool
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBufferSize {
+		return
+	}
+
+	bufferPool.Put(b)
+}
+
+// rawStrings marks the information and debug strings that are embedded as raw JSON. Nil marks embed none
+type rawStrings struct {
+	info  []bool
+	debug []bool
+}
+
+// appendErrorJSON appends the error data in the typego.Error JSON format. The output is the same as json.Marshal, except
+// the invalid UTF-8 as explained by appendJSONString and the strings marked as raw JSON
+func appendErrorJSON(dst []byte, d *ErrorData, raw rawStrings) ([]byte, error) {
+	var err error
+
+	dst = append(dst, `{"level":`...)
+	dst = appendJSONString(dst, d.Level)
+
+	if d.Timestamp != 0 {
+		dst = append(dst, `,"timestamp":`...)
+		dst = appendTimestampJSON(dst, d.Timestamp)
+	}
+
+	if d.ProcessID != "" {
+		dst = append(dst, `,"process_id":`...)
+		dst = appendJSONString(dst, d.ProcessID)
+	}
+
+	if d.ProcessName != "" {
+		dst = append(dst, `,"process_name":`...)
+		dst = appendJSONString(dst, d.ProcessName)
+	}
+
+	dst = append(dst, `,"code":`...)
+	dst = appendJSONString(dst, d.Code)
+	dst = append(dst, `,"message":`...)
+	dst = appendJSONString(dst, d.Message)
+
+	if d.DeveloperMessage != "" {
+		dst = append(dst, `,"developer_message":`...)
+		dst = appendJSONString(dst, d.DeveloperMessage)
+	}
+
+	dst = append(dst, `,"info":`...)
+	dst = appendStringsJSON(dst, d.Info, raw.info)
+
+	if len(d.Fields) > 0 {
+		dst = append(dst, `,"fields":`...)
+
+		if dst, err = appendFieldsJSON(dst, d.Fields); err != nil {
+			return dst, err
+		}
+	}
+
+	if d.HttpStatus != 0 {
+		dst = append(dst, `,"http_status":`...)
+		dst = strconv.AppendInt(dst, int64(d.HttpStatus), 10)
+	}
+
+	if d.RPCStatus != 0 {
+		dst = append(dst, `,"rpc_status":`...)
+		dst = strconv.AppendInt(dst, int64(d.RPCStatus), 10)
+	}
+
+	if len(d.Debug) > 0 {
+		dst = append(dst, `,"debug":`...)
+		dst = appendStringsJSON(dst, d.Debug, raw.debug)
+	}
+
+	if len(d.Stack) > 0 {
+		dst = append(dst, `,"stack":`...)
+		dst = appendStringsJSON(dst, d.Stack, nil)
+	}
+
+	if d.Suppressed != 0 {
+		dst = append(dst, `,"suppressed":`...)
+		dst = strconv.AppendUint(dst, d.Suppressed, 10)
+	}
+
+	return append(dst, '}'), nil
+}
+
+// appendInfoJSON appends the information data in the typego.Info JSON format. The output is the same as json.Marshal,
+// except the invalid UTF-8 as explained by appendJSONString and the strings marked as raw JSON
+func appendInfoJSON(dst []byte, d *InfoData, raw rawStrings) ([]byte, error) {
+	var err error
+
+	dst = append(dst, `{"level":`...)
+	dst = appendJSONString(dst, d.Level)
+
+	if d.Timestamp != 0 {
+		dst = append(dst, `,"timestamp":`...)
+		dst = appendTimestampJSON(dst, d.Timestamp)
+	}
+
+	if d.ProcessID != "" {
+		dst = append(dst, `,"process_id":`...)
+		dst = appendJSONString(dst, d.ProcessID)
+	}
+
+	if d.ProcessName != "" {
+		dst = append(dst, `,"process_name":`...)
+		dst = appendJSONString(dst, d.ProcessName)
+	}
+
+	dst = append(dst, `,"info":`...)
+	dst = appendStringsJSON(dst, d.Info, raw.info)
+
+	if len(d.Fields) > 0 {
+		dst = append(dst, `,"fields":`...)
+
+		if dst, err = appendFieldsJSON(dst, d.Fields); err != nil {
+			return dst, err
+		}
+	}
+
+	if len(d.Debug) > 0 {
+		dst = append(dst, `,"debug":`...)
+		dst = appendStringsJSON(dst, d.Debug, raw.debug)
+	}
+
+	if d.DurationMS != 0 {
+		dst = append(dst, `,"duration_ms":`...)
+
+		if dst, err = appendFloatJSON(dst, d.DurationMS, 64); err != nil {
+			return dst, err
+		}
+	}
+
+	if d.Suppressed != 0 {
+		dst = append(dst, `,"suppressed":`...)
+		dst = strconv.AppendUint(dst, d.Suppressed, 10)
+	}
+
+	return append(dst, '}'), nil
+}
+
+// appendPublicErrorJSON appends the public view of the error. The output is the same as json.Marshal of publicError,
+// except the invalid UTF-8 as explained by appendJSONString and the information strings marked as raw JSON
+func appendPublicErrorJSON(dst []byte, p *publicError) []byte {
+	dst = append(dst, '{')
+
+	if p.ProcessID != "" {
+		dst = append(dst, `"process_id":`...)
+		dst = appendJSONString(dst, p.ProcessID)
+		dst = append(dst, ',')
+	}
+
+	dst = append(dst, `"code":`...)
+	dst = appendJSONString(dst, p.Code)
+	dst = append(dst, `,"message":`...)
+	dst = appendJSONString(dst, p.Message)
+	dst = append(dst, `,"info":`...)
+	dst = appendStringsJSON(dst, p.Info, p.infoRaw)
+
+	return append(dst, '}')
+}
+
+// appendTimestampJSON appends the timestamp according to the layout set by SetTimestampLayout
+func appendTimestampJSON(dst []byte, t Timestamp) []byte {
+	if TimestampLayout(timestampLayout.Load()) == TimestampUnixMilli {
+		return strconv.AppendInt(dst, t.Time().UnixMilli(), 10)
+	}
+
+	dst = append(dst, '"')
+	dst = t.Time().AppendFormat(dst, time.RFC3339Nano)
+
+	return append(dst, '"')
+}
+
+// appendStringsJSON appends the strings as a JSON array, or `null` if the strings are nil. A string marked as raw is
+// appended as a compacted raw JSON value like json.RawMessage
+func appendStringsJSON(dst []byte, values []string, raw []bool) []byte {
+	if values == nil {
+		return append(dst, "null"...)
+	}
+
+	dst = append(dst, '[')
+
+	for i, v := range values {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+
+		if i < len(raw) && raw[i] {
+			if b, err := json.Marshal(json.RawMessage(v)); err == nil {
+				dst = append(dst, b...)
+				continue
+			}
+		}
+
+		dst = appendJSONString(dst, v)
+	}
+
+	return append(dst, ']')
+}
+
+// appendFieldsJSON appends the fields as a JSON object with the keys sorted like json.Marshal does
+func appendFieldsJSON(dst []byte, fields map[string]any) ([]byte, error) {
+	var (
+		stack [16]string
+		err   error
+	)
+
+	keys := stack[:0]
+
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	dst = append(dst, '{')
+
+	for i, k := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+
+		dst = appendJSONString(dst, k)
+		dst = append(dst, ':')
+
+		if dst, err = appendValueJSON(dst, fields[k]); err != nil {
+			return dst, err
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// appendValueJSON appends the value as JSON. The common types are written directly, and the other types are
+// serialized by json.Marshal
+func appendValueJSON(dst []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return appendJSONString(dst, v), nil
+	case bool:
+		return strconv.AppendBool(dst, v), nil
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, v, 10), nil
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(dst, v, 10), nil
+	case float32:
+		return appendFloatJSON(dst, float64(v), 32)
+	case float64:
+		return appendFloatJSON(dst, v, 64)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return dst, err
+	}
+
+	return append(dst, b...), nil
+}
+
+// appendFloatJSON appends the float like json.Marshal does. NaN and infinity are not valid JSON, so they are passed to
+// json.Marshal to get the same error
+func appendFloatJSON(dst []byte, f float64, bits int) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		_, err := json.Marshal(f)
+		return dst, err
+	}
+
+	format := byte('f')
+
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+
+	return dst, nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends the string as a JSON string with the same escaping as json.Marshal. The HTML characters and
+// the U+2028 and U+2029 line separators are escaped. Each invalid UTF-8 byte is replaced by the U+FFFD character
+// itself, like the newer json.Marshal does, while the older versions write the `\ufffd` escape. Both decode to
+// the same string
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+
+			dst = append(dst, s[start:i]...)
+
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+
+			i++
+			start = i
+
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+			i += size
+			start = i
+
+			continue
+		}
+
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+
+			continue
+		}
+
+		i += size
+	}
+
+	dst = append(dst, s[start:]...)
+
+	return append(dst, '"')
+}
@@ -0,0 +1,142 @@
+package typego_test
+
+import (
+	"encoding/json"
+	"github.com/dalikewara/typego"
+	"log"
+	"math"
+	"strings"
+	"testing"
+)
+
+// marshalJSON encodes the value by json.Marshal with each `\ufffd` escape written as the U+FFFD character itself, like
+// the newer json.Marshal does, so the expected output is the same on every Go version
+func marshalJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var builder strings.Builder
+
+	for i := 0; i < len(b); i++ {
+		if b[i] == '\\' {
+			if strings.HasPrefix(string(b[i:]), `\ufffd`) {
+				builder.WriteRune('\uFFFD')
+				i += len(`\ufffd`) - 1
+				continue
+			}
+
+			builder.WriteByte(b[i])
+			i++
+		}
+
+		if i < len(b) {
+			builder.WriteByte(b[i])
+		}
+	}
+
+	return []byte(builder.String()), nil
+}
+
+func TestErrorModel_MarshalJSON_matchesEncodingJSON(t *testing.T) {
+	data := fullErrorData(typego.LevelError)
+	data.Message = "<b>\"quoted\" & \\escaped\\</b>\n\t\r\b\f\x01    \xff ünïcødé"
+	data.Fields["float"] = 1.5
+	data.Fields["small"] = 0.0000001
+	data.Fields["large"] = 1e21
+	data.Fields["float32"] = float32(0.1)
+	data.Fields["int8"] = int8(-8)
+	data.Fields["uint64"] = uint64(math.MaxUint64)
+	data.Fields["nil"] = nil
+	data.Fields["map"] = map[string]any{"<key>": []int{1, 2}}
+
+	for _, d := range []typego.ErrorData{data, {Level: typego.LevelError, Code: "01"}} {
+		expected, err := marshalJSON(d)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		b, err := json.Marshal(typego.ErrorFromData(d))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if string(b) != string(expected) {
+			log.Fatal("`b` must be `" + string(expected) + "`")
+		}
+
+		if errString := typego.ErrorFromData(d).InternalJSON(); errString != string(expected) {
+			log.Fatal("`errString` must be `" + string(expected) + "`")
+		}
+	}
+}
+
+func TestInfoModel_MarshalJSON_matchesEncodingJSON(t *testing.T) {
+	data := typego.InfoData{
+		Level:       typego.LevelNotice,
+		Timestamp:   typego.NewTimestamp(testTime),
+		ProcessID:   "123",
+		ProcessName: "test",
+		Info:        []string{"raw <info>"},
+		Fields:      map[string]any{"b": true, "a": json.Number("1.50")},
+		Debug:       []string{"raw debug"},
+		DurationMS:  0.000000125,
+		Suppressed:  2,
+	}
+
+	for _, d := range []typego.InfoData{data, {Level: typego.LevelInfo}} {
+		expected, err := marshalJSON(d)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		b, err := json.Marshal(typego.InfoFromData(d))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if string(b) != string(expected) {
+			log.Fatal("`b` must be `" + string(expected) + "`")
+		}
+
+		if infoString := typego.InfoFromData(d).String(); infoString != string(expected) {
+			log.Fatal("`infoString` must be `" + string(expected) + "`")
+		}
+	}
+}
+
+func TestErrorModel_MarshalJSON_unsupportedValue(t *testing.T) {
+	if _, err := json.Marshal(typego.NewError("01", "").AddField("nan", math.NaN())); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func FuzzErrorModel_InternalJSON(f *testing.F) {
+	f.Add("general error", "raw info", 1.5)
+	f.Add("<script>&</script>", " \xff\"", 1e-7)
+	f.Add("\x00\x1f\\", "", -1e21)
+
+	f.Fuzz(func(t *testing.T, message string, info string, value float64) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return
+		}
+
+		d := typego.ErrorData{
+			Level:   typego.LevelError,
+			Code:    info,
+			Message: message,
+			Info:    []string{info, message},
+			Fields:  map[string]any{message: value, info: float32(value)},
+		}
+
+		expected, err := marshalJSON(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if errString := typego.ErrorFromData(d).InternalJSON(); errString != string(expected) {
+			t.Fatalf("`errString` must be `%s`, got `%s`", expected, errString)
+		}
+	})
+}
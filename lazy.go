@@ -0,0 +1,262 @@
+package typego
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+)
+
+// lazyStrings is a list of values that are stringified on first use, so adding information does not pay for the
+// serialization until the information is read, encoded or logged. Only the immutable scalar values are kept, and they
+// are stringified with the redactor and the JSON string mode active when they were added. A node is shared by the
+// copies of a model, so it is never changed after it is created, except by its own resolution
+type lazyStrings struct {
+	once     sync.Once
+	parent   *lazyStrings
+	values   []any
+	redactor *Redactor
+	mode     JSONStringMode
+	strings  []string
+	raw      []bool
+}
+
+// stringified is a value already stringified when it was added
+type stringified string
+
+// newLazyStrings generates new lazyStrings holding the already stringified values. The raw marks tell which strings
+// are embedded as raw JSON, and they may be nil if there are none
+func newLazyStrings(strings []string, raw []bool) *lazyStrings {
+	l := &lazyStrings{
+		strings: strings,
+		raw:     raw,
+	}
+
+	// the strings are already resolved
+	l.once.Do(func() {})
+
+	return l
+}
+
+// add returns new lazyStrings holding the strings of l followed by the values. The scalar values are kept to be
+// stringified later, and the other values, which the caller could still change, are stringified now
+func (l *lazyStrings) add(values []any) *lazyStrings {
+	n := &lazyStrings{
+		parent:   l,
+		values:   make([]any, len(values)),
+		redactor: activeRedactor(),
+		mode:     JSONStringMode(jsonStringMode.Load()),
+	}
+
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64,
+			json.Number:
+			n.values[i] = v
+		case error:
+			n.values[i] = v.Error()
+		default:
+			n.values[i] = stringified(stringifyValue(n.redactor, n.mode, v))
+		}
+	}
+
+	return n
+}
+
+// resolve stringifies the pending values once and returns all strings. The returned slice must not be changed
+func (l *lazyStrings) resolve() []string {
+	if l == nil {
+		return nil
+	}
+
+	l.once.Do(func() {
+		parent := l.parent.resolve()
+		parentRaw := l.parent.rawMarks()
+
+		strings := make([]string, len(parent), len(parent)+len(l.values))
+		copy(strings, parent)
+
+		var raw []bool
+
+		if parentRaw != nil {
+			raw = make([]bool, len(parent), cap(strings))
+			copy(raw, parentRaw)
+		}
+
+		for _, value := range l.values {
+			s, isString := value.(string)
+
+			if v, ok := value.(stringified); ok {
+				s = string(v)
+			} else {
+				s = stringifyValue(l.redactor, l.mode, value)
+			}
+
+			// only the strings stored in the raw mode are embedded as raw JSON, and only if they are still a valid JSON
+			// object or array after the redaction
+			isRaw := isString && l.mode == JSONStringRaw && isJSONContainer(s)
+
+			if isRaw && raw == nil {
+				raw = make([]bool, len(strings), cap(strings))
+			}
+
+			if raw != nil {
+				raw = append(raw, isRaw)
+			}
+
+			strings = append(strings, s)
+		}
+
+		l.strings = strings
+		l.raw = raw
+		l.parent = nil
+		l.values = nil
+	})
+
+	return l.strings
+}
+
+// rawMarks returns the marks of the strings embedded as raw JSON, or nil if there are none. The returned slice must
+// not be changed
+func (l *lazyStrings) rawMarks() []bool {
+	if l == nil {
+		return nil
+	}
+
+	l.resolve()
+
+	return l.raw
+}
+
+// addStrings adds the values to the current strings or to the lazy strings, and returns both. When there are no lazy
+// strings yet and every value is a plain string, the strings are appended at once, so the new slice is the only
+// allocation. Otherwise the values are added to the lazy strings, with the current strings as their base
+func addStrings(l *lazyStrings, current []string, values []any) ([]string, *lazyStrings) {
+	if l == nil {
+		if strings, ok := appendPlainStrings(current, values); ok {
+			return strings, nil
+		}
+
+		if current != nil {
+			l = newLazyStrings(current, nil)
+		}
+	}
+
+	return current, l.add(values)
+}
+
+// appendPlainStrings returns a new slice of the current strings followed by the stringified values, if every value is
+// a string that is not embedded as raw JSON. The current slice is shared, so it is never appended to in place
+func appendPlainStrings(current []string, values []any) ([]string, bool) {
+	mode := JSONStringMode(jsonStringMode.Load())
+
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok || (mode == JSONStringRaw && isJSONContainer(s)) {
+			return nil, false
+		}
+	}
+
+	r := activeRedactor()
+
+	strings := make([]string, len(current), len(current)+len(values))
+	copy(strings, current)
+
+	for _, value := range values {
+		strings = append(strings, stringifyValue(r, mode, value))
+	}
+
+	return strings, true
+}
+
+// jsonStrings decodes the information or debug strings. A JSON object or array in the list is kept as its raw JSON
+// string and marked as raw, so it is embedded as raw JSON again when the entry is encoded
+type jsonStrings struct {
+	strings []string
+	raw     []bool
+}
+
+func (s *jsonStrings) UnmarshalJSON(b []byte) error {
+	var values []json.RawMessage
+
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+
+	if values == nil {
+		return nil
+	}
+
+	s.strings = make([]string, len(values))
+
+	for i, value := range values {
+		if value[0] == '{' || value[0] == '[' {
+			if s.raw == nil {
+				s.raw = make([]bool, len(values))
+			}
+
+			s.strings[i] = string(value)
+			s.raw[i] = true
+
+			continue
+		}
+
+		if err := json.Unmarshal(value, &s.strings[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lazy returns the decoded strings, and the lazy strings holding them with the raw marks if there is a raw JSON
+func (s jsonStrings) lazy() ([]string, *lazyStrings) {
+	if s.raw == nil {
+		return s.strings, nil
+	}
+
+	return s.strings, newLazyStrings(s.strings, s.raw)
+}
+
+// encodingGeneration changes with the settings that change the output of typego.Error.Error(), so the outputs cached
+// with the previous settings are not used anymore
+var encodingGeneration atomic.Uint64
+
+// cachedError is an output of typego.Error.Error() and the encoding generation it was made with
+type cachedError struct {
+	generation uint64
+	value      string
+}
+
+// errorCache caches the output of typego.Error.Error(). It is part of the error, and a builder that changes the error
+// does not copy it
+type errorCache struct {
+	value atomic.Pointer[cachedError]
+}
+
+// load gets the cached output made with the given encoding generation
+func (c *errorCache) load(generation uint64) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	v := c.value.Load()
+	if v == nil || v.generation != generation {
+		return "", false
+	}
+
+	return v.value, true
+}
+
+// reset removes the cached output
+func (c *errorCache) reset() {
+	c.value.Store(nil)
+}
+
+// store caches the output made with the given encoding generation
+func (c *errorCache) store(generation uint64, value string) {
+	if c == nil {
+		return
+	}
+
+	c.value.Store(&cachedError{generation: generation, value: value})
+}
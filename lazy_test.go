@@ -0,0 +1,178 @@
+package typego_test
+
+import (
+	"github.com/dalikewara/typego"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type lazyValue struct {
+	Name string `json:"name"`
+}
+
+func TestErrorModel_AddInfo_lazy(t *testing.T) {
+	base := typego.NewError("01", "").AddInfo("a")
+	x := base.AddInfo("b")
+	y := base.AddInfo(lazyValue{Name: "c"})
+
+	if baseInfo := strings.Join(base.GetInfo(), ","); baseInfo != "a" {
+		log.Fatal("`baseInfo` must be `a`")
+	}
+
+	if xInfo := strings.Join(x.GetInfo(), ","); xInfo != "a,b" {
+		log.Fatal("`xInfo` must be `a,b`")
+	}
+
+	if yInfo := strings.Join(y.GetInfo(), ","); yInfo != `a,{"name":"c"}` {
+		log.Fatal("`yInfo` must be `a,{\"name\":\"c\"}`")
+	}
+
+	if errInfo := typego.NewError("01", "").AddInfo().GetInfo(); errInfo != nil {
+		log.Fatal("`errInfo` must be nil")
+	}
+
+	if errDebug := strings.Join(x.AddDebug("d").AddDebug(1).GetDebug(), ","); errDebug != "d,1" {
+		log.Fatal("`errDebug` must be `d,1`")
+	}
+}
+
+func TestErrorModel_AddInfo_lazyConcurrent(t *testing.T) {
+	err := typego.NewError("01", "").AddInfo("a", 1).AddDebug("b")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if errInfo := strings.Join(err.GetInfo(), ","); errInfo != "a,1" {
+				log.Fatal("`errInfo` must be `a,1`")
+			}
+
+			if errString := err.Error(); !strings.Contains(errString, `"debug":["b"]`) {
+				log.Fatal("`errString` must contain the debug")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestInfoModel_AddInfo_lazy(t *testing.T) {
+	base := typego.NewInfo().AddInfo("a")
+	x := base.AddInfo("b").AddDebug("c")
+
+	if baseInfo := strings.Join(base.GetInfo(), ","); baseInfo != "a" {
+		log.Fatal("`baseInfo` must be `a`")
+	}
+
+	if xString := x.String(); xString != `{"level":"info","timestamp":"2024-01-01T00:00:00Z","info":["a","b"],"debug":["c"]}` {
+		log.Fatal("`xString` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":[\"a\",\"b\"],\"debug\":[\"c\"]}`")
+	}
+
+	if infoInfo := typego.NewInfo().AddInfo().GetInfo(); infoInfo != nil {
+		log.Fatal("`infoInfo` must be nil")
+	}
+}
+
+func TestErrorModel_Error_cacheInvalidatedByBuilders(t *testing.T) {
+	defer typego.SetDefaultBundle(typego.DefaultBundle())
+
+	b := typego.NewBundle()
+	_ = b.Add("id", map[string]string{"01": "Terjadi kesalahan"})
+
+	typego.SetDefaultBundle(b)
+
+	base := typego.NewError("01", "general error")
+	baseString := base.Error()
+
+	builders := map[string]func(err typego.Error) typego.Error{
+		`"code":"02"`:                      func(err typego.Error) typego.Error { return err.ChangeCode("02") },
+		`"message":"changed"`:              func(err typego.Error) typego.Error { return err.ChangeMessage("changed") },
+		`"developer_message":"db"`:         func(err typego.Error) typego.Error { return err.SetDeveloperMessage("db") },
+		`"message":"Terjadi kesalahan"`:    func(err typego.Error) typego.Error { return err.Localize("id") },
+		`"info":["raw info"]`:              func(err typego.Error) typego.Error { return err.AddInfo("raw info") },
+		`"fields":{"name":"test"}`:         func(err typego.Error) typego.Error { return err.AddField("name", "test") },
+		`"fields":{"paid":true}`:           func(err typego.Error) typego.Error { return err.AddFields(map[string]any{"paid": true}) },
+		`"debug":["raw debug"]`:            func(err typego.Error) typego.Error { return err.AddDebug("raw debug") },
+		`"process_id":"123"`:               func(err typego.Error) typego.Error { return err.SetProcessID("123") },
+		`"process_name":"test"`:            func(err typego.Error) typego.Error { return err.SetProcessName("test") },
+		`"http_status":500`:                func(err typego.Error) typego.Error { return err.SetHttpStatus(500) },
+		`"rpc_status":13`:                  func(err typego.Error) typego.Error { return err.SetRPCStatus(13) },
+		`"stack":[`:                        func(err typego.Error) typego.Error { return err.WithStack() },
+		`"message":"general error","info"`: func(err typego.Error) typego.Error { return err.WithStack().WithoutStack() },
+	}
+
+	for expected, build := range builders {
+		err := build(base)
+
+		if errString := err.Error(); !strings.Contains(errString, expected) {
+			log.Fatal("`errString` must contain `" + expected + "`, got `" + errString + "`")
+		}
+
+		if errString := base.Error(); errString != baseString {
+			log.Fatal("`errString` must be `" + baseString + "`")
+		}
+	}
+}
+
+func TestErrorModel_Error_cacheInvalidatedBySettings(t *testing.T) {
+	err := typego.NewError("01", "general error").SetProcessID("123").AddInfo("raw info")
+
+	if errString := err.Error(); errString != `{"level":"error","timestamp":"2024-01-01T00:00:00Z","process_id":"123","code":"01","message":"general error","info":["raw info"]}` {
+		log.Fatal("`errString` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw info\"]}`")
+	}
+
+	typego.SetTimestampLayout(typego.TimestampUnixMilli)
+
+	if errString := err.Error(); errString != `{"level":"error","timestamp":1704067200000,"process_id":"123","code":"01","message":"general error","info":["raw info"]}` {
+		log.Fatal("`errString` must be `{\"level\":\"error\",\"timestamp\":1704067200000,\"process_id\":\"123\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw info\"]}`")
+	}
+
+	typego.SetTimestampLayout(typego.TimestampRFC3339Nano)
+
+	if errString := err.Error(); errString != err.InternalJSON() {
+		log.Fatal("`errString` must be the internal json")
+	}
+}
+
+func TestErrorModel_AddInfo_snapshot(t *testing.T) {
+	values := map[string]string{"a": "b"}
+	list := []string{"c"}
+
+	err := typego.NewError("01", "").AddInfo(values).AddDebug(list)
+
+	values["a"] = "changed"
+	list[0] = "changed"
+
+	if errInfo := strings.Join(err.GetInfo(), ","); errInfo != `{"a":"b"}` {
+		log.Fatal("`errInfo` must be `{\"a\":\"b\"}`, got `" + errInfo + "`")
+	}
+
+	if errDebug := strings.Join(err.GetDebug(), ","); errDebug != `["c"]` {
+		log.Fatal("`errDebug` must be `[\"c\"]`, got `" + errDebug + "`")
+	}
+}
+
+func TestErrorModel_AddInfo_settingsWhenAdded(t *testing.T) {
+	defer typego.SetRedactor(typego.GetRedactor())
+	defer typego.SetJSONStringMode(typego.JSONStringClean)
+
+	r := typego.NewRedactor(typego.RedactMask)
+	_ = r.AddKeyPattern("secret")
+
+	typego.SetRedactor(r)
+
+	err := typego.NewError("01", "").AddInfo(map[string]string{"secret": "x"}, `{"a":1}`)
+
+	typego.SetRedactor(nil)
+	typego.SetJSONStringMode(typego.JSONStringRaw)
+
+	if errInfo := strings.Join(err.GetInfo(), ","); errInfo != `{"secret":"[REDACTED]"},{a: 1}` {
+		log.Fatal("`errInfo` must be `{\"secret\":\"[REDACTED]\"},{a: 1}`, got `" + errInfo + "`")
+	}
+}
@@ -0,0 +1,11 @@
+package typego
+
+// Levels of typego.Error and typego.Info
+const (
+	LevelDebug   = "debug"
+	LevelInfo    = "info"
+	LevelNotice  = "notice"
+	LevelWarning = "warning"
+	LevelError   = "error"
+	LevelFatal   = "fatal"
+)
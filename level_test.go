@@ -0,0 +1,91 @@
+package typego_test
+
+import (
+	"github.com/dalikewara/typego"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestNewDebugEntry(t *testing.T) {
+	if level := typego.NewDebugEntry().GetLevel(); level != typego.LevelDebug {
+		log.Fatal("`level` must be `debug`")
+	}
+}
+
+func TestNewNotice(t *testing.T) {
+	if level := typego.NewNotice().GetLevel(); level != typego.LevelNotice {
+		log.Fatal("`level` must be `notice`")
+	}
+}
+
+func TestNewWarning(t *testing.T) {
+	if warning := typego.NewWarning().AddInfo("disk almost full").String(); warning != "{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":[\"disk almost full\"]}" {
+		log.Fatal("`warning` must be `{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":[\"disk almost full\"]}`")
+	}
+}
+
+func TestNewFatal(t *testing.T) {
+	if level := typego.NewFatal().GetLevel(); level != typego.LevelFatal {
+		log.Fatal("`level` must be `fatal`")
+	}
+}
+
+func TestSetCustomLevelLog(t *testing.T) {
+	logged := make(map[string]int)
+	handler := func(info typego.Info) {
+		logged[info.GetLevel()]++
+	}
+
+	typego.SetCustomDebugLog(handler)
+	typego.SetCustomNoticeLog(handler)
+	typego.SetCustomWarningLog(handler)
+
+	_ = typego.NewDebugEntry().Log()
+	_ = typego.NewNotice().Log()
+	_ = typego.NewWarning().Log()
+	_ = typego.NewWarning().Log()
+
+	if logged[typego.LevelDebug] != 1 || logged[typego.LevelNotice] != 1 || logged[typego.LevelWarning] != 2 {
+		log.Fatal("each level must be logged by its own handler")
+	}
+
+	if logged[typego.LevelInfo] != 0 {
+		log.Fatal("`info` level must not be logged")
+	}
+}
+
+func TestSetFatalExitCode(t *testing.T) {
+	var exitCode int
+	var flushed bool
+	var logged typego.Info
+
+	typego.SetExit(func(code int) {
+		exitCode = code
+	})
+	defer typego.SetExit(os.Exit)
+
+	typego.SetCustomFatalLog(func(info typego.Info) {
+		logged = info
+	})
+	typego.SetFatalExitCode(3)
+	defer typego.SetFatalExitCode(1)
+
+	typego.AddFlushHook(func() {
+		flushed = logged != nil
+	})
+
+	_ = typego.NewFatal().AddInfo("cannot start").Log()
+
+	if logged == nil {
+		log.Fatal("`logged` must not nil")
+	}
+
+	if !flushed {
+		log.Fatal("flush hooks must be called after the entry is logged")
+	}
+
+	if exitCode != 3 {
+		log.Fatal("`exitCode` must be `3`")
+	}
+}
@@ -0,0 +1,150 @@
+package typego
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// LogLimit configures the rate limiting and sampling of Log(). The entries are limited per level, code and process
+// name, so a flooding error does not suppress the other entries
+type LogLimit struct {
+	// Rate is the number of entries per second allowed for each level, code and process name. Zero or negative rate
+	// disables rate limiting
+	Rate float64
+
+	// Burst is the number of entries allowed at once. The default is the rate rounded up, at least 1
+	Burst int
+
+	// Sampling is the probability (0, 1] that an entry is logged. Zero or greater than 1 sampling logs every entry
+	Sampling float64
+}
+
+const (
+	// minLogBucketSweep is the number of buckets from which the idle buckets are evicted
+	minLogBucketSweep = 1024
+
+	// maxLogBuckets caps the number of buckets. The buckets are dropped with their suppressed counts if there are still
+	// more after the idle buckets are evicted
+	maxLogBuckets = 1 << 16
+)
+
+type logBucket struct {
+	tokens     float64
+	last       Timestamp
+	suppressed uint64
+}
+
+var logLimiter = struct {
+	sync.Mutex
+	limit   LogLimit
+	buckets map[string]*logBucket
+	sweepAt int
+}{
+	buckets: make(map[string]*logBucket),
+	sweepAt: minLogBucketSweep,
+}
+
+var sample = rand.Float64
+
+// SetLogLimit sets the rate limiting and sampling of typego.Error.Log() and typego.Info.Log(). The number of
+// suppressed entries is reported in the `suppressed` member of the next logged entry of the same level, code and
+// process name. Fatal entries are never suppressed. The zero LogLimit disables it
+func SetLogLimit(limit LogLimit) {
+	if limit.Rate > 0 && limit.Burst <= 0 {
+		limit.Burst = int(math.Max(1, math.Ceil(limit.Rate)))
+	}
+
+	logLimiter.Lock()
+	defer logLimiter.Unlock()
+
+	logLimiter.limit = limit
+	logLimiter.buckets = make(map[string]*logBucket)
+	logLimiter.sweepAt = minLogBucketSweep
+}
+
+// allowLog reports whether the entry of the level, code and process name is logged. If it is, it returns the number
+// of entries suppressed since the last logged entry
+func allowLog(level string, code string, processName string) (uint64, bool) {
+	if level == LevelFatal {
+		return 0, true
+	}
+
+	logLimiter.Lock()
+	defer logLimiter.Unlock()
+
+	limit := logLimiter.limit
+	sampled := limit.Sampling > 0 && limit.Sampling < 1
+
+	if limit.Rate <= 0 && !sampled {
+		return 0, true
+	}
+
+	key := level + "\x00" + code + "\x00" + processName
+
+	b, ok := logLimiter.buckets[key]
+	if !ok {
+		if len(logLimiter.buckets) >= logLimiter.sweepAt {
+			evictLogBuckets(limit)
+		}
+
+		b = &logBucket{
+			tokens: float64(limit.Burst),
+			last:   NewTimestamp(now()),
+		}
+
+		logLimiter.buckets[key] = b
+	}
+
+	if sampled && sample() >= limit.Sampling {
+		b.suppressed++
+		return 0, false
+	}
+
+	if limit.Rate > 0 {
+		t := NewTimestamp(now())
+		elapsed := t.Time().Sub(b.last.Time()).Seconds()
+
+		if elapsed > 0 {
+			b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+			b.last = t
+		}
+
+		if b.tokens < 1 {
+			b.suppressed++
+			return 0, false
+		}
+
+		b.tokens--
+	}
+
+	suppressed := b.suppressed
+	b.suppressed = 0
+
+	return suppressed, true
+}
+
+// evictLogBuckets removes the buckets that are the same as new ones, which are full again and have no suppressed
+// entries, so the buckets of the codes and process names that are not logged anymore do not grow the map forever. The
+// next eviction happens when the number of buckets doubles
+func evictLogBuckets(limit LogLimit) {
+	t := NewTimestamp(now())
+
+	for key, b := range logLimiter.buckets {
+		if b.suppressed > 0 {
+			continue
+		}
+
+		if limit.Rate > 0 && b.tokens+t.Time().Sub(b.last.Time()).Seconds()*limit.Rate < float64(limit.Burst) {
+			continue
+		}
+
+		delete(logLimiter.buckets, key)
+	}
+
+	if len(logLimiter.buckets) >= maxLogBuckets {
+		logLimiter.buckets = make(map[string]*logBucket)
+	}
+
+	logLimiter.sweepAt = max(minLogBucketSweep, 2*len(logLimiter.buckets))
+}
@@ -0,0 +1,146 @@
+package typego_test
+
+import (
+	"github.com/dalikewara/typego"
+	"log"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSetLogLimit(t *testing.T) {
+	var logged []typego.Entry
+
+	_ = typego.DefaultPipeline().AddSink("log_limit", typego.SinkFunc(func(entry typego.Entry) error {
+		logged = append(logged, entry)
+		return nil
+	}))
+	defer typego.DefaultPipeline().RemoveSink("log_limit")
+	defer typego.SetLogLimit(typego.LogLimit{})
+	defer typego.SetClock(func() time.Time {
+		return testTime
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		logged = nil
+
+		typego.SetLogLimit(typego.LogLimit{Rate: 2})
+
+		for i := 0; i < 5; i++ {
+			typego.NewError("01", "general error").Log()
+		}
+
+		typego.NewError("02", "general error").Log()
+		typego.NewError("01", "general error").SetProcessName("test").Log()
+
+		if len(logged) != 4 {
+			log.Fatal("`logged` must have 4 entries")
+		}
+
+		typego.SetClock(func() time.Time {
+			return testTime.Add(time.Second)
+		})
+
+		typego.NewError("01", "general error").Log()
+
+		if err, ok := logged[4].(typego.Error); !ok || err.GetSuppressed() != 3 {
+			log.Fatal("`suppressed` must be `3`")
+		}
+
+		if err := logged[4].(typego.Error).Error(); err != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:01Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"suppressed\":3}" {
+			log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:01Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"suppressed\":3}`")
+		}
+
+		typego.NewError("01", "general error").Log()
+
+		if err := logged[5].(typego.Error); err.GetSuppressed() != 0 {
+			log.Fatal("`suppressed` must be `0`")
+		}
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		logged = nil
+
+		samples := []float64{0.1, 0.9, 0.8, 0.2}
+
+		typego.SetSample(func() float64 {
+			s := samples[0]
+			samples = samples[1:]
+
+			return s
+		})
+		defer typego.SetSample(nil)
+
+		typego.SetLogLimit(typego.LogLimit{Sampling: 0.5})
+
+		for i := 0; i < 4; i++ {
+			typego.NewInfo().Log()
+		}
+
+		if len(logged) != 2 || logged[0].(typego.Info).GetSuppressed() != 0 || logged[1].(typego.Info).GetSuppressed() != 2 {
+			log.Fatal("`logged` must have 2 entries and the last one must report 2 suppressed entries")
+		}
+	})
+
+	t.Run("fatal", func(t *testing.T) {
+		logged = nil
+
+		typego.SetExit(func(code int) {})
+		defer typego.SetExit(os.Exit)
+
+		typego.SetLogLimit(typego.LogLimit{Rate: 1})
+
+		for i := 0; i < 3; i++ {
+			typego.NewFatal().Log()
+		}
+
+		if len(logged) != 3 {
+			log.Fatal("`logged` must have 3 entries")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		logged = nil
+
+		typego.SetLogLimit(typego.LogLimit{})
+
+		for i := 0; i < 3; i++ {
+			typego.NewWarning().Log()
+		}
+
+		if len(logged) != 3 {
+			log.Fatal("`logged` must have 3 entries")
+		}
+	})
+}
+
+func TestSetLogLimit_evictIdleBuckets(t *testing.T) {
+	defer typego.SetLogLimit(typego.LogLimit{})
+	defer typego.SetClock(func() time.Time {
+		return testTime
+	})
+
+	typego.SetLogLimit(typego.LogLimit{Rate: 1})
+
+	typego.AllowLog(typego.LevelError, "busy", "")
+	typego.AllowLog(typego.LevelError, "busy", "")
+
+	for i := 0; i < 1023; i++ {
+		typego.AllowLog(typego.LevelError, strconv.Itoa(i), "")
+	}
+
+	typego.SetClock(func() time.Time {
+		return testTime.Add(time.Second)
+	})
+
+	typego.AllowLog(typego.LevelError, "new", "")
+
+	if buckets := typego.LogBuckets(); buckets != 2 {
+		log.Fatal("`buckets` must be `2`, got `" + strconv.Itoa(buckets) + "`")
+	}
+
+	if !typego.AllowLog(typego.LevelError, "busy", "") {
+		log.Fatal("`busy` must be allowed after its bucket is refilled")
+	}
+}
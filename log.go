@@ -1,38 +1,221 @@
 package typego
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 )
 
-var errorLogHandler = func(err Error) {
-	b, e := json.Marshal(err)
-	if e != nil {
-		fmt.Println(e)
+// consoleLog is the sink used by the default log handlers instead of the JSON lines after UseConsoleLog is called,
+// until UseJSONLog is called
+var consoleLog atomic.Pointer[ConsoleSink]
+
+var defaultErrorLogHandler = func(err Error) {
+	if c := consoleLog.Load(); c != nil {
+		_ = c.Write(err)
+		return
 	}
 
-	fmt.Println(string(b))
+	fmt.Println(encodeEntry(err))
 }
 
-var infoLogHandler = func(info Info) {
-	b, e := json.Marshal(info)
-	if e != nil {
-		fmt.Println(e)
+var defaultInfoLogHandler = func(info Info) {
+	if c := consoleLog.Load(); c != nil {
+		_ = c.Write(info)
+		return
 	}
 
-	fmt.Println(string(b))
+	fmt.Println(encodeEntry(info))
+}
+
+var logHandlers = struct {
+	sync.RWMutex
+	error   ErrorLogHandler
+	info    InfoLogHandler
+	debug   InfoLogHandler
+	notice  InfoLogHandler
+	warning InfoLogHandler
+	fatal   InfoLogHandler
+}{
+	error:   defaultErrorLogHandler,
+	info:    defaultInfoLogHandler,
+	debug:   defaultInfoLogHandler,
+	notice:  defaultInfoLogHandler,
+	warning: defaultInfoLogHandler,
+	fatal:   defaultInfoLogHandler,
+}
+
+var fatalExitCode = 1
+
+var exit = os.Exit
+
+var flushHooks = struct {
+	sync.Mutex
+	hooks []*flushHook
+}{}
+
+// flushHook is a registered flush hook. Its pointer identifies the registration, so it can be removed
+type flushHook struct {
+	hook func()
 }
 
 type ErrorLogHandler func(err Error)
 
 type InfoLogHandler func(info Info)
 
-// SetCustomErrorLog sets custom error log handler
+// SetCustomErrorLog sets custom error log handler. The handler is called by the default sink of the default pipeline
 func SetCustomErrorLog(handler ErrorLogHandler) {
-	errorLogHandler = handler
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	logHandlers.error = handler
 }
 
-// SetCustomInfoLog sets custom info log handler
+// SetCustomInfoLog sets custom info log handler. The handler is called by the default sink of the default pipeline
 func SetCustomInfoLog(handler InfoLogHandler) {
-	infoLogHandler = handler
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	logHandlers.info = handler
+}
+
+// SetCustomDebugLog sets custom debug log handler. The handler is called by the default sink of the default pipeline
+func SetCustomDebugLog(handler InfoLogHandler) {
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	logHandlers.debug = handler
+}
+
+// SetCustomNoticeLog sets custom notice log handler. The handler is called by the default sink of the default
+// pipeline
+func SetCustomNoticeLog(handler InfoLogHandler) {
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	logHandlers.notice = handler
+}
+
+// SetCustomWarningLog sets custom warning log handler. The handler is called by the default sink of the default
+// pipeline
+func SetCustomWarningLog(handler InfoLogHandler) {
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	logHandlers.warning = handler
+}
+
+// SetCustomFatalLog sets custom fatal log handler. The handler is called by the default sink of the default pipeline
+func SetCustomFatalLog(handler InfoLogHandler) {
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	logHandlers.fatal = handler
+}
+
+// SetFatalExitCode sets the exit code used when a fatal entry is logged. The default exit code is 1
+func SetFatalExitCode(code int) {
+	logHandlers.Lock()
+	defer logHandlers.Unlock()
+
+	fatalExitCode = code
+}
+
+// AddFlushHook adds a hook that is called before the program exits because a fatal entry is logged, so buffered log
+// sinks can be flushed. The default pipeline is always flushed after the hooks
+func AddFlushHook(hook func()) {
+	addFlushHook(hook)
+}
+
+// addFlushHook adds the flush hook and returns the function that removes it
+func addFlushHook(hook func()) func() {
+	h := &flushHook{hook: hook}
+
+	flushHooks.Lock()
+	defer flushHooks.Unlock()
+
+	flushHooks.hooks = append(flushHooks.hooks, h)
+
+	return func() {
+		flushHooks.Lock()
+		defer flushHooks.Unlock()
+
+		for i, registered := range flushHooks.hooks {
+			if registered == h {
+				flushHooks.hooks = append(flushHooks.hooks[:i:i], flushHooks.hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func getErrorLogHandler() ErrorLogHandler {
+	logHandlers.RLock()
+	defer logHandlers.RUnlock()
+
+	return logHandlers.error
+}
+
+func getInfoLogHandler(level string) InfoLogHandler {
+	logHandlers.RLock()
+	defer logHandlers.RUnlock()
+
+	switch level {
+	case LevelDebug:
+		return logHandlers.debug
+	case LevelNotice:
+		return logHandlers.notice
+	case LevelWarning:
+		return logHandlers.warning
+	case LevelFatal:
+		return logHandlers.fatal
+	}
+
+	return logHandlers.info
+}
+
+func logError(err *errorModel) {
+	suppressed, ok := allowLog(err.Level, err.Code, err.ProcessName)
+	if !ok {
+		return
+	}
+
+	if err.Suppressed != suppressed {
+		err = err.clone()
+		err.Suppressed = suppressed
+	}
+
+	DefaultPipeline().Log(err)
+}
+
+func logInfo(info infoModel) {
+	suppressed, ok := allowLog(info.Level, "", info.ProcessName)
+	if ok {
+		info.Suppressed = suppressed
+
+		DefaultPipeline().Log(&info)
+	}
+
+	if info.GetLevel() == LevelFatal {
+		flush()
+
+		logHandlers.RLock()
+		code := fatalExitCode
+		logHandlers.RUnlock()
+
+		exit(code)
+	}
+}
+
+func flush() {
+	flushHooks.Lock()
+	hooks := append([]*flushHook(nil), flushHooks.hooks...)
+	flushHooks.Unlock()
+
+	for _, h := range hooks {
+		h.hook()
+	}
+
+	_ = DefaultPipeline().Flush()
 }
@@ -0,0 +1,19 @@
+package typego_test
+
+import (
+	"github.com/dalikewara/typego"
+	"os"
+	"testing"
+	"time"
+)
+
+// testTime is the time of every entry generated by the tests, so the expected JSON strings are deterministic
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	typego.SetClock(func() time.Time {
+		return testTime
+	})
+
+	os.Exit(m.Run())
+}
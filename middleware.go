@@ -0,0 +1,178 @@
+package typego
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const (
+	defaultProcessIDHeader = "X-Request-ID"
+	panicErrorCode         = "PANIC"
+)
+
+var processIDHeader = struct {
+	sync.RWMutex
+	value string
+}{
+	value: defaultProcessIDHeader,
+}
+
+// SetProcessIDHeader sets the http header used by Middleware to read and write the process id. The default header is
+// `X-Request-ID`
+func SetProcessIDHeader(header string) {
+	processIDHeader.Lock()
+	defer processIDHeader.Unlock()
+
+	processIDHeader.value = http.CanonicalHeaderKey(header)
+}
+
+// HandlerFunc is an http handler that returns an error instead of writing the error response manually. The returned
+// error is logged and written as `application/problem+json`
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls f(w, r) and handles the returned error
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		handleHTTPError(w, r, toHTTPError(err))
+	}
+}
+
+// Middleware recovers panics into typego.Error with stack trace, assigns a process id from the request header (or
+// generates one) to the request context and the request and response headers, then logs and writes the recovered
+// error as `application/problem+json`
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := getProcessIDHeader()
+
+		processID := r.Header.Get(header)
+		if processID == "" {
+			processID = newProcessID()
+			r.Header.Set(header, processID)
+		}
+
+		w.Header().Set(header, processID)
+
+		r = r.WithContext(WithProcessID(r.Context(), processID))
+		rw := &responseWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			err := panicError(rec)
+
+			if rw.wroteHeader {
+				err.LogCtx(r.Context())
+				return
+			}
+
+			handleHTTPError(rw, r, err)
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+func handleHTTPError(w http.ResponseWriter, r *http.Request, err Error) {
+	ctx := r.Context()
+
+	if ProcessIDFromContext(ctx) == "" {
+		ctx = WithProcessID(ctx, r.Header.Get(getProcessIDHeader()))
+	}
+
+	err = err.LogCtx(ctx)
+
+	if err.GetProcessID() == "" {
+		if processID := ProcessIDFromContext(ctx); processID != "" {
+			err = err.SetProcessID(processID)
+		}
+	}
+
+	WriteHTTP(w, err)
+}
+
+// toHTTPError converts the error to typego.Error. An error that is not typego.Error is wrapped as an internal server
+// error, so its message does not leak to the response
+func toHTTPError(err error) Error {
+	var e Error
+
+	if errors.As(err, &e) {
+		return e
+	}
+
+	return Wrap(err, GetDefaultErrorCode(), http.StatusText(http.StatusInternalServerError)).
+		SetHttpStatus(http.StatusInternalServerError).
+		AddDebug(err)
+}
+
+func panicError(rec any) Error {
+	var cause error
+
+	switch v := rec.(type) {
+	case error:
+		cause = v
+	default:
+		cause = fmt.Errorf("%v", v)
+	}
+
+	return Wrap(cause, panicErrorCode, http.StatusText(http.StatusInternalServerError)).
+		SetHttpStatus(http.StatusInternalServerError).
+		AddDebug(cause).
+		WithStack()
+}
+
+func getProcessIDHeader() string {
+	processIDHeader.RLock()
+	defer processIDHeader.RUnlock()
+
+	return processIDHeader.value
+}
+
+func newProcessID() string {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// responseWriter records whether the response header has been written, so a recovered panic is not written over a
+// partial response
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (rw *responseWriter) WriteHeader(statusCode int) {
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(b)
+}
+
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		rw.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original http.ResponseWriter, so http.ResponseController can reach it
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,160 @@
+package typego_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dalikewara/typego"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var logged typego.Error
+
+	previous := typego.GetErrorLog()
+	t.Cleanup(func() {
+		typego.SetCustomErrorLog(previous)
+	})
+
+	typego.SetCustomErrorLog(func(err typego.Error) {
+		logged = err
+	})
+
+	handler := typego.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("something went wrong")
+		}
+
+		_, _ = w.Write([]byte(r.Header.Get("X-Request-ID") + "," + typego.ProcessIDFromContext(r.Context())))
+	}))
+
+	t.Run("panic", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+
+		req.Header.Set("X-Request-ID", "123")
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != 500 {
+			log.Fatal("`rec.Code` must be `500`")
+		}
+
+		if processID := rec.Header().Get("X-Request-ID"); processID != "123" {
+			log.Fatal("`processID` must be `123`")
+		}
+
+		if body := rec.Body.String(); !strings.Contains(body, "\"code\":\"PANIC\"") || strings.Contains(body, "something went wrong") {
+			log.Fatal("`body` must contain code `PANIC` without the panic value")
+		}
+
+		if body := rec.Body.String(); !strings.Contains(body, "\"instance\":\"urn:typego:process:123\"") {
+			log.Fatal("`body` must contain instance `urn:typego:process:123`")
+		}
+
+		if logged == nil || logged.GetProcessID() != "123" || len(logged.GetStack()) == 0 {
+			log.Fatal("`logged` must have process id `123` and stack trace")
+		}
+
+		if errDebug := logged.GetDebug(); len(errDebug) != 1 || errDebug[0] != "something went wrong" {
+			log.Fatal("`errDebug` must be `[]string{\"something went wrong\"}`")
+		}
+	})
+
+	t.Run("generated_process_id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != 200 {
+			log.Fatal("`rec.Code` must be `200`")
+		}
+
+		processID := rec.Header().Get("X-Request-ID")
+
+		if len(processID) != 32 {
+			log.Fatal("`processID` must be generated")
+		}
+
+		if body := rec.Body.String(); body != processID+","+processID {
+			log.Fatal("`body` must contain `processID` from the request header and context")
+		}
+	})
+}
+
+func TestSetProcessIDHeader(t *testing.T) {
+	typego.SetProcessIDHeader("x-correlation-id")
+	defer typego.SetProcessIDHeader("X-Request-ID")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	req.Header.Set("X-Correlation-ID", "123")
+
+	typego.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req)
+
+	if processID := rec.Header().Get("X-Correlation-ID"); processID != "123" {
+		log.Fatal("`processID` must be `123`")
+	}
+}
+
+func TestHandlerFunc_ServeHTTP(t *testing.T) {
+	previous := typego.GetErrorLog()
+	t.Cleanup(func() {
+		typego.SetCustomErrorLog(previous)
+	})
+
+	typego.SetCustomErrorLog(func(err typego.Error) {})
+
+	handler := typego.Middleware(typego.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		switch r.URL.Path {
+		case "/typego":
+			return fmt.Errorf("find user: %w", typego.NewError("01", "user not found").SetHttpStatus(404))
+		case "/error":
+			return errors.New("connection refused")
+		}
+
+		return nil
+	}))
+
+	t.Run("typego", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/typego", nil))
+
+		if rec.Code != 404 {
+			log.Fatal("`rec.Code` must be `404`")
+		}
+
+		if body := rec.Body.String(); !strings.Contains(body, "\"code\":\"01\"") {
+			log.Fatal("`body` must contain code `01`")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/error", nil))
+
+		if rec.Code != 500 {
+			log.Fatal("`rec.Code` must be `500`")
+		}
+
+		if body := rec.Body.String(); !strings.Contains(body, "\"code\":\"UNKNOWN\"") || strings.Contains(body, "connection refused") {
+			log.Fatal("`body` must contain code `UNKNOWN` without the error message")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != 200 {
+			log.Fatal("`rec.Code` must be `200`")
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package typego
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// StatusRule decides the http status of typego.MultiError from the http statuses of its errors. The http status of an
+// error without http status is 500
+type StatusRule int
+
+const (
+	// StatusRuleHighest uses the highest http status
+	StatusRuleHighest StatusRule = iota
+
+	// StatusRuleFirst uses the http status of the first error
+	StatusRuleFirst
+
+	// StatusRuleUniform uses the http status shared by every error. If the errors have different http statuses, it
+	// uses 400 when every http status is 4xx, otherwise, 500
+	StatusRuleUniform
+)
+
+type MultiError interface {
+	// Add adds the errors and returns its instance. Nil errors are ignored
+	Add(errs ...Error) MultiError
+
+	// SetStatusRule sets the rule of the http status and returns its instance
+	SetStatusRule(rule StatusRule) MultiError
+
+	// Errors gets the errors
+	Errors() []Error
+
+	// Len gets the number of errors
+	Len() int
+
+	// ErrorOrNil returns nil if there is no error, otherwise, its instance
+	ErrorOrNil() error
+
+	// GetHttpStatus gets the http status decided by the status rule, or 0 if there is no error
+	GetHttpStatus() int
+
+	// Log logs every error and return its instance
+	Log() MultiError
+
+	// LogCtx logs every error with the process id, process name and fields carried by the context, and return its
+	// instance
+	LogCtx(ctx context.Context) MultiError
+
+	// Unwrap returns the errors, so they are reachable by errors.Is and errors.As
+	Unwrap() []error
+
+	// PublicJSON returns the JSON array of the public JSON of the errors, which is the client facing output
+	PublicJSON() string
+
+	// Error returns the JSON array of the internal JSON of the errors
+	Error() string
+}
+
+type multiErrorModel struct {
+	errs []Error
+	rule StatusRule
+}
+
+func (m multiErrorModel) Add(errs ...Error) MultiError {
+	merged := make([]Error, 0, len(m.errs)+len(errs))
+	merged = append(merged, m.errs...)
+
+	for _, err := range errs {
+		if err != nil {
+			merged = append(merged, err)
+		}
+	}
+
+	m.errs = merged
+
+	return m
+}
+
+func (m multiErrorModel) SetStatusRule(rule StatusRule) MultiError {
+	m.rule = rule
+	return m
+}
+
+func (m multiErrorModel) Errors() []Error {
+	return append([]Error(nil), m.errs...)
+}
+
+func (m multiErrorModel) Len() int {
+	return len(m.errs)
+}
+
+func (m multiErrorModel) ErrorOrNil() error {
+	if len(m.errs) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+func (m multiErrorModel) GetHttpStatus() int {
+	if len(m.errs) == 0 {
+		return 0
+	}
+
+	switch m.rule {
+	case StatusRuleFirst:
+		return httpStatusOf(m.errs[0])
+	case StatusRuleUniform:
+		status := httpStatusOf(m.errs[0])
+		allClientErrors := true
+
+		for _, err := range m.errs {
+			s := httpStatusOf(err)
+
+			if s != status {
+				status = 0
+			}
+
+			if s < 400 || s >= 500 {
+				allClientErrors = false
+			}
+		}
+
+		if status != 0 {
+			return status
+		}
+
+		if allClientErrors {
+			return http.StatusBadRequest
+		}
+
+		return http.StatusInternalServerError
+	default:
+		status := 0
+
+		for _, err := range m.errs {
+			if s := httpStatusOf(err); s > status {
+				status = s
+			}
+		}
+
+		return status
+	}
+}
+
+func (m multiErrorModel) Log() MultiError {
+	for _, err := range m.errs {
+		err.Log()
+	}
+
+	return m
+}
+
+func (m multiErrorModel) LogCtx(ctx context.Context) MultiError {
+	for _, err := range m.errs {
+		err.LogCtx(ctx)
+	}
+
+	return m
+}
+
+func (m multiErrorModel) Unwrap() []error {
+	errs := make([]error, len(m.errs))
+
+	for i, err := range m.errs {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+func (m multiErrorModel) PublicJSON() string {
+	return m.jsonArray(Error.PublicJSON)
+}
+
+func (m multiErrorModel) Error() string {
+	return m.jsonArray(Error.InternalJSON)
+}
+
+// MarshalJSON encodes the errors in the JSON array of Error()
+func (m multiErrorModel) MarshalJSON() ([]byte, error) {
+	return []byte(m.Error()), nil
+}
+
+// jsonArray returns the JSON array of the errors encoded by the encode function
+func (m multiErrorModel) jsonArray(encode func(Error) string) string {
+	var b strings.Builder
+
+	b.WriteByte('[')
+
+	for i, err := range m.errs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(encode(err))
+	}
+
+	b.WriteByte(']')
+
+	return b.String()
+}
+
+// NewMultiError generates new typego.MultiError with the errors. Nil errors are ignored
+func NewMultiError(errs ...Error) MultiError {
+	return multiErrorModel{}.Add(errs...)
+}
@@ -0,0 +1,168 @@
+package typego_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"testing"
+)
+
+func TestNewMultiError(t *testing.T) {
+	if m := typego.NewMultiError(typego.NewError("01", ""), nil); m.Len() != 1 {
+		log.Fatal("`m.Len()` must be `1`")
+	}
+}
+
+func TestMultiErrorModel_Add(t *testing.T) {
+	m := typego.NewMultiError(typego.NewError("01", ""))
+	m2 := m.Add(typego.NewError("02", ""), nil)
+
+	if m.Len() != 1 {
+		log.Fatal("`m.Len()` must be `1`")
+	}
+
+	if m2.Len() != 2 {
+		log.Fatal("`m2.Len()` must be `2`")
+	}
+}
+
+func TestMultiErrorModel_Errors(t *testing.T) {
+	errs := typego.NewMultiError(typego.NewError("01", ""), typego.NewError("02", "")).Errors()
+
+	if len(errs) != 2 || errs[0].GetCode() != "01" || errs[1].GetCode() != "02" {
+		log.Fatal("`errs` must be `[01 02]`")
+	}
+}
+
+func TestMultiErrorModel_ErrorOrNil(t *testing.T) {
+	if err := typego.NewMultiError().ErrorOrNil(); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if err := typego.NewMultiError(typego.NewError("01", "")).ErrorOrNil(); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestMultiErrorModel_GetHttpStatus(t *testing.T) {
+	m := typego.NewMultiError(typego.NewError("01", "").SetHttpStatus(404), typego.NewError("02", "").SetHttpStatus(422))
+
+	if status := typego.NewMultiError().GetHttpStatus(); status != 0 {
+		log.Fatal("`status` must be `0`")
+	}
+
+	if status := m.GetHttpStatus(); status != 422 {
+		log.Fatal("`status` must be `422`")
+	}
+
+	if status := m.SetStatusRule(typego.StatusRuleFirst).GetHttpStatus(); status != 404 {
+		log.Fatal("`status` must be `404`")
+	}
+
+	if status := m.SetStatusRule(typego.StatusRuleUniform).GetHttpStatus(); status != 400 {
+		log.Fatal("`status` must be `400`")
+	}
+
+	if status := m.Add(typego.NewError("03", "")).SetStatusRule(typego.StatusRuleUniform).GetHttpStatus(); status != 500 {
+		log.Fatal("`status` must be `500`")
+	}
+
+	if status := typego.NewMultiError(typego.NewError("01", "").SetHttpStatus(409), typego.NewError("02", "").SetHttpStatus(409)).SetStatusRule(typego.StatusRuleUniform).GetHttpStatus(); status != 409 {
+		log.Fatal("`status` must be `409`")
+	}
+}
+
+func TestMultiErrorModel_Log(t *testing.T) {
+	var codes []string
+
+	_ = typego.DefaultPipeline().AddSink("multi_error", typego.SinkFunc(func(entry typego.Entry) error {
+		if err, ok := entry.(typego.Error); ok {
+			codes = append(codes, err.GetCode()+err.GetProcessID())
+		}
+
+		return nil
+	}))
+	defer typego.DefaultPipeline().RemoveSink("multi_error")
+
+	m := typego.NewMultiError(typego.NewError("01", ""), typego.NewError("02", ""))
+
+	m.Log()
+	m.LogCtx(typego.WithProcessID(context.Background(), "123"))
+
+	if len(codes) != 4 || codes[0] != "01" || codes[1] != "02" || codes[2] != "01123" || codes[3] != "02123" {
+		log.Fatal("`codes` must be `[01 02 01123 02123]`")
+	}
+}
+
+func TestMultiErrorModel_Unwrap(t *testing.T) {
+	errNotFound := typego.NewError("404", "not found")
+
+	var err error = typego.NewMultiError(typego.NewError("01", ""), errNotFound.SetProcessID("123"))
+
+	if !errors.Is(err, errNotFound) {
+		log.Fatal("`err` must be `errNotFound`")
+	}
+
+	var target typego.Error
+
+	if !errors.As(err, &target) || target.GetCode() != "01" {
+		log.Fatal("`target` must be the first error")
+	}
+
+	if joined := errors.Join(err, errors.New("raw error")); !errors.Is(joined, errNotFound) {
+		log.Fatal("`joined` must be `errNotFound`")
+	}
+}
+
+func TestMultiErrorModel_Error(t *testing.T) {
+	if err := typego.NewMultiError().Error(); err != "[]" {
+		log.Fatal("`err` must be `[]`")
+	}
+
+	if err := typego.NewMultiError(typego.NewError("01", "general error"), typego.NewError("02", "not found").SetHttpStatus(404)).Error(); err != "[{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null},{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"02\",\"message\":\"not found\",\"info\":null,\"http_status\":404}]" {
+		log.Fatal("`err` must be `[{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null},{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"02\",\"message\":\"not found\",\"info\":null,\"http_status\":404}]`")
+	}
+
+	typego.SetEncoder(typego.NewLogfmtEncoder())
+	defer typego.SetEncoder(nil)
+
+	if err := typego.NewMultiError(typego.NewError("01", "general error")).Error(); err != "[{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}]" {
+		log.Fatal("`err` must be `[{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}]`")
+	}
+}
+
+func TestMultiErrorModel_PublicJSON(t *testing.T) {
+	if s := typego.NewMultiError().PublicJSON(); s != "[]" {
+		log.Fatal("`s` must be `[]`")
+	}
+
+	if s := typego.NewMultiError(typego.NewError("01", "general error").AddDebug("debug")).PublicJSON(); s != "[{\"code\":\"01\",\"message\":\"general error\",\"info\":null}]" {
+		log.Fatal("`s` must be `[{\"code\":\"01\",\"message\":\"general error\",\"info\":null}]`")
+	}
+}
+
+func TestMultiErrorModel_MarshalJSON(t *testing.T) {
+	m := typego.NewMultiError(typego.NewError("01", "general error"))
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if string(b) != m.Error() {
+		log.Fatal("`b` must be `m.Error()`")
+	}
+
+	b, err = json.Marshal(struct {
+		Errors typego.MultiError `json:"errors"`
+	}{Errors: m})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if string(b) != "{\"errors\":"+m.Error()+"}" {
+		log.Fatal("`b` must contain `m.Error()`")
+	}
+}
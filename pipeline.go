@@ -0,0 +1,359 @@
+package typego
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSinkName is the name of the sink registered in the default pipeline. It forwards the entries to the log
+// handlers set by SetCustomErrorLog, SetCustomInfoLog and the other level handler setters
+const DefaultSinkName = "default"
+
+// ErrDuplicateSink is returned when adding a sink with a name that is already registered
+var ErrDuplicateSink = errors.New("typego: duplicate sink name")
+
+// ErrSinkNotFound is returned when configuring a sink that is not registered
+var ErrSinkNotFound = errors.New("typego: sink not found")
+
+// Entry is a logged typego.Error or typego.Info
+type Entry interface {
+	GetLevel() string
+	GetTimestamp() time.Time
+	GetProcessID() string
+	GetProcessName() string
+	GetInfo() []string
+	GetFields() map[string]any
+	GetDebug() []string
+}
+
+// Sink writes log entries
+type Sink interface {
+	Write(entry Entry) error
+}
+
+// SinkFunc adapts a function to typego.Sink
+type SinkFunc func(entry Entry) error
+
+// Write calls f(entry)
+func (f SinkFunc) Write(entry Entry) error {
+	return f(entry)
+}
+
+// Flusher is implemented by sinks that buffer the entries
+type Flusher interface {
+	Flush() error
+}
+
+// SinkErrorHandler handles the error returned by a sink
+type SinkErrorHandler func(name string, entry Entry, err error)
+
+// Pipeline dispatches log entries to multiple sinks. Sinks can be added and removed concurrently while entries are
+// being logged
+type Pipeline struct {
+	mu    sync.RWMutex
+	sinks []*pipelineSink
+}
+
+type pipelineSink struct {
+	name         string
+	sink         Sink
+	levels       map[string]struct{}
+	errorHandler SinkErrorHandler
+}
+
+var defaultPipeline atomic.Pointer[Pipeline]
+
+func init() {
+	p := NewPipeline()
+
+	_ = p.AddSink(DefaultSinkName, handlerSink{})
+
+	defaultPipeline.Store(p)
+}
+
+// DefaultPipeline gets the pipeline used by typego.Error.Log() and typego.Info.Log()
+func DefaultPipeline() *Pipeline {
+	return defaultPipeline.Load()
+}
+
+// SetDefaultPipeline sets the pipeline used by typego.Error.Log() and typego.Info.Log()
+func SetDefaultPipeline(p *Pipeline) {
+	defaultPipeline.Store(p)
+}
+
+// NewPipeline generates new typego.Pipeline without sinks
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// AddSink adds a sink with a unique name. The sink receives only the entries of the given levels, or every entry if
+// no level is given. It returns ErrDuplicateSink if the name is already registered
+func (p *Pipeline) AddSink(name string, sink Sink, levels ...string) error {
+	ps := &pipelineSink{
+		name:         name,
+		sink:         sink,
+		errorHandler: defaultSinkErrorHandler,
+	}
+
+	if len(levels) > 0 {
+		ps.levels = make(map[string]struct{}, len(levels))
+
+		for _, level := range levels {
+			ps.levels[level] = struct{}{}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, s := range p.sinks {
+		if s.name == name {
+			return fmt.Errorf("%w: %s", ErrDuplicateSink, name)
+		}
+	}
+
+	sinks := make([]*pipelineSink, 0, len(p.sinks)+1)
+	sinks = append(sinks, p.sinks...)
+	p.sinks = append(sinks, ps)
+
+	return nil
+}
+
+// RemoveSink removes the sink of the name. It reports whether the sink was registered
+func (p *Pipeline) RemoveSink(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, s := range p.sinks {
+		if s.name == name {
+			sinks := make([]*pipelineSink, 0, len(p.sinks)-1)
+			sinks = append(sinks, p.sinks[:i]...)
+			p.sinks = append(sinks, p.sinks[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetSinkErrorHandler sets the handler of the errors returned by the sink of the name. By default, the errors are
+// printed to the standard error. It returns ErrSinkNotFound if the sink is not registered
+func (p *Pipeline) SetSinkErrorHandler(name string, handler SinkErrorHandler) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, s := range p.sinks {
+		if s.name == name {
+			ps := *s
+			ps.errorHandler = handler
+
+			sinks := make([]*pipelineSink, len(p.sinks))
+			copy(sinks, p.sinks)
+			sinks[i] = &ps
+			p.sinks = sinks
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSinkNotFound, name)
+}
+
+// Sinks returns the names of the registered sinks in the order they were added
+func (p *Pipeline) Sinks() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	names := make([]string, 0, len(p.sinks))
+
+	for _, s := range p.sinks {
+		names = append(names, s.name)
+	}
+
+	return names
+}
+
+// Log writes the entry to every sink that accepts the entry level
+func (p *Pipeline) Log(entry Entry) {
+	p.mu.RLock()
+	sinks := p.sinks
+	p.mu.RUnlock()
+
+	level := entry.GetLevel()
+
+	for _, s := range sinks {
+		if s.levels != nil {
+			if _, ok := s.levels[level]; !ok {
+				continue
+			}
+		}
+
+		if err := s.sink.Write(entry); err != nil && s.errorHandler != nil {
+			s.errorHandler(s.name, entry, err)
+		}
+	}
+}
+
+// Flush flushes every sink that implements typego.Flusher
+func (p *Pipeline) Flush() error {
+	p.mu.RLock()
+	sinks := p.sinks
+	p.mu.RUnlock()
+
+	var errs []error
+
+	for _, s := range sinks {
+		if flusher, ok := s.sink.(Flusher); ok {
+			if err := flusher.Flush(); err != nil {
+				errs = append(errs, fmt.Errorf("typego: flush sink %s: %w", s.name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close flushes and removes every sink, and closes the sinks that implement io.Closer
+func (p *Pipeline) Close() error {
+	err := p.Flush()
+
+	p.mu.Lock()
+	sinks := p.sinks
+	p.sinks = nil
+	p.mu.Unlock()
+
+	errs := []error{err}
+
+	for _, s := range sinks {
+		if closer, ok := s.sink.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("typego: close sink %s: %w", s.name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func defaultSinkErrorHandler(name string, _ Entry, err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "typego: sink %s: %v\n", name, err)
+}
+
+// WriterSink writes every entry as a line to an io.Writer. The entries are encoded by the encoder of the sink, or by the
+// global encoder if the sink has no encoder
+type WriterSink struct {
+	mu      sync.Mutex
+	w       io.Writer
+	closer  io.Closer
+	encoder Encoder
+}
+
+// NewWriterSink generates new typego.WriterSink that writes to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{
+		w: w,
+	}
+}
+
+// NewStdoutSink generates new typego.WriterSink that writes to the standard output
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
+
+// NewFileSink generates new typego.WriterSink that appends to the file of the path. The file is created if it does
+// not exist, and closed when the sink is closed
+func NewFileSink(path string) (*WriterSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriterSink{
+		w:      f,
+		closer: f,
+	}, nil
+}
+
+// SetEncoder sets the encoder of the sink and returns the sink. A nil encoder makes the sink use the global encoder
+func (s *WriterSink) SetEncoder(encoder Encoder) *WriterSink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.encoder = encoder
+
+	return s
+}
+
+// Write writes the entry as a line
+func (s *WriterSink) Write(entry Entry) error {
+	s.mu.Lock()
+	enc := s.encoder
+	s.mu.Unlock()
+
+	if enc == nil {
+		enc = GetEncoder()
+	}
+
+	b, err := enc.Encode(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(b, '\n'))
+
+	return err
+}
+
+// Flush flushes the writer if it is buffered or syncs it if it is a file
+func (s *WriterSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch w := s.w.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case *os.File:
+		if w == os.Stdout || w == os.Stderr {
+			return nil
+		}
+
+		return w.Sync()
+	}
+
+	return nil
+}
+
+// Close closes the file opened by NewFileSink. It does nothing for other writers
+func (s *WriterSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closer == nil {
+		return nil
+	}
+
+	return s.closer.Close()
+}
+
+// handlerSink forwards the entries to the log handlers of their levels
+type handlerSink struct{}
+
+func (handlerSink) Write(entry Entry) error {
+	switch v := entry.(type) {
+	case Error:
+		getErrorLogHandler()(v)
+	case Info:
+		getInfoLogHandler(v.GetLevel())(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package typego_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/dalikewara/typego"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestNewPipeline(t *testing.T) {
+	if p := typego.NewPipeline(); p == nil || len(p.Sinks()) != 0 {
+		log.Fatal("`p` must not nil and have no sinks")
+	}
+}
+
+func TestDefaultPipeline(t *testing.T) {
+	if sinks := typego.DefaultPipeline().Sinks(); fmt.Sprintf("%v", sinks) != fmt.Sprintf("%v", []string{typego.DefaultSinkName}) {
+		log.Fatal("`sinks` must be `[]string{\"default\"}`")
+	}
+}
+
+func TestSetDefaultPipeline(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := typego.NewPipeline()
+	_ = p.AddSink("buffer", typego.NewWriterSink(&buf))
+
+	original := typego.DefaultPipeline()
+
+	typego.SetDefaultPipeline(p)
+	defer typego.SetDefaultPipeline(original)
+
+	_ = typego.NewError("01", "general error").Log()
+	_ = typego.NewInfo().AddInfo("raw info").Log()
+
+	if output := buf.String(); output != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}\n{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":[\"raw info\"]}\n" {
+		log.Fatal("`output` must contain the error and the information")
+	}
+}
+
+func TestPipeline_AddSink(t *testing.T) {
+	var errorBuf, allBuf bytes.Buffer
+
+	p := typego.NewPipeline()
+
+	if err := p.AddSink("error", typego.NewWriterSink(&errorBuf), typego.LevelError, typego.LevelFatal); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if err := p.AddSink("all", typego.NewWriterSink(&allBuf)); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if err := p.AddSink("all", typego.NewWriterSink(&allBuf)); !errors.Is(err, typego.ErrDuplicateSink) {
+		log.Fatal("`err` must be `typego.ErrDuplicateSink`")
+	}
+
+	p.Log(typego.NewError("01", "general error"))
+	p.Log(typego.NewWarning())
+
+	if output := errorBuf.String(); output != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}\n" {
+		log.Fatal("`output` must contain the error only")
+	}
+
+	if output := allBuf.String(); output != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}\n{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null}\n" {
+		log.Fatal("`output` must contain the error and the warning")
+	}
+}
+
+func TestPipeline_RemoveSink(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := typego.NewPipeline()
+	_ = p.AddSink("buffer", typego.NewWriterSink(&buf))
+
+	if !p.RemoveSink("buffer") {
+		log.Fatal("`RemoveSink` must be `true`")
+	}
+
+	if p.RemoveSink("buffer") {
+		log.Fatal("`RemoveSink` must be `false`")
+	}
+
+	p.Log(typego.NewInfo())
+
+	if buf.Len() != 0 {
+		log.Fatal("`buf` must empty")
+	}
+}
+
+func TestPipeline_SetSinkErrorHandler(t *testing.T) {
+	var handled error
+	var written int
+
+	p := typego.NewPipeline()
+	_ = p.AddSink("failing", typego.SinkFunc(func(entry typego.Entry) error {
+		return errors.New("sink error")
+	}))
+	_ = p.AddSink("working", typego.SinkFunc(func(entry typego.Entry) error {
+		written++
+		return nil
+	}))
+
+	if err := p.SetSinkErrorHandler("failing", func(name string, entry typego.Entry, err error) {
+		handled = fmt.Errorf("%s: %w", name, err)
+	}); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if err := p.SetSinkErrorHandler("unknown", nil); !errors.Is(err, typego.ErrSinkNotFound) {
+		log.Fatal("`err` must be `typego.ErrSinkNotFound`")
+	}
+
+	p.Log(typego.NewInfo())
+
+	if handled == nil || handled.Error() != "failing: sink error" {
+		log.Fatal("`handled` must be `failing: sink error`")
+	}
+
+	if written != 1 {
+		log.Fatal("`written` must be `1`")
+	}
+}
+
+func TestPipeline_Log(t *testing.T) {
+	var count int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	p := typego.NewPipeline()
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("sink-%d", i)
+
+			_ = p.AddSink(name, typego.SinkFunc(func(entry typego.Entry) error {
+				mu.Lock()
+				count++
+				mu.Unlock()
+
+				return nil
+			}))
+			_ = p.RemoveSink(name)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			p.Log(typego.NewInfo())
+		}()
+	}
+
+	wg.Wait()
+
+	if sinks := p.Sinks(); len(sinks) != 0 {
+		log.Fatal("`sinks` must empty")
+	}
+}
+
+func TestPipeline_Flush(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := bufio.NewWriter(&buf)
+	p := typego.NewPipeline()
+	_ = p.AddSink("buffer", typego.NewWriterSink(w))
+
+	p.Log(typego.NewInfo())
+
+	if buf.Len() != 0 {
+		log.Fatal("`buf` must empty before flushed")
+	}
+
+	if err := p.Flush(); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if output := buf.String(); output != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null}\n" {
+		log.Fatal("`output` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null}`")
+	}
+}
+
+func TestPipeline_Close(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "typego.log")
+
+	sink, err := typego.NewFileSink(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := typego.NewPipeline()
+	_ = p.AddSink("file", sink)
+
+	p.Log(typego.NewError("01", "general error"))
+
+	if err := p.Close(); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if sinks := p.Sinks(); len(sinks) != 0 {
+		log.Fatal("`sinks` must empty")
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if output := string(b); output != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}\n" {
+		log.Fatal("`output` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null}`")
+	}
+}
+
+func TestNewFileSink(t *testing.T) {
+	if _, err := typego.NewFileSink(filepath.Join(t.TempDir(), "missing", "typego.log")); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestNewStdoutSink(t *testing.T) {
+	if err := typego.NewStdoutSink().Write(typego.NewInfo()); err != nil {
+		log.Fatal("`err` must nil")
+	}
+}
@@ -0,0 +1,187 @@
+package typego
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemInstancePrefix is the prefix of the problem instance generated from the process id
+const problemInstancePrefix = "urn:typego:process:"
+
+// ErrNotProblem is returned when decoding a response that is not a problem details document
+var ErrNotProblem = errors.New("typego: response is not a problem details document")
+
+// ProblemDetails represents RFC 9457 (formerly RFC 7807) problem details. The `code`, `info` and `debug` members are
+// typego extension members
+type ProblemDetails struct {
+	Type     string   `json:"type,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Status   int      `json:"status,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Instance string   `json:"instance,omitempty"`
+	Code     string   `json:"code,omitempty"`
+	Info     []string `json:"info,omitempty"`
+	Debug    []string `json:"debug,omitempty"`
+}
+
+var problemDebugEnabled atomic.Bool
+
+var problemTypeBaseURI = struct {
+	sync.RWMutex
+	value string
+}{}
+
+// SetProblemDebug includes or excludes the debug information in problem details. The debug information is excluded
+// by default
+func SetProblemDebug(enabled bool) {
+	problemDebugEnabled.Store(enabled)
+}
+
+// SetProblemTypeBaseURI sets the base URI of the problem type. When it is set, the problem type is the base URI
+// followed by the error code, otherwise, the problem type is `about:blank`
+func SetProblemTypeBaseURI(baseURI string) {
+	problemTypeBaseURI.Lock()
+	defer problemTypeBaseURI.Unlock()
+
+	problemTypeBaseURI.value = baseURI
+}
+
+// NewProblemDetails generates new typego.ProblemDetails from a typego.Error. The http status defaults to 500 when the
+// error has no http status. The instance is `urn:typego:process:` followed by the process id of the error, if it has
+// one
+func NewProblemDetails(err Error) ProblemDetails {
+	status := httpStatusOf(err)
+
+	problemTypeBaseURI.RLock()
+	baseURI := problemTypeBaseURI.value
+	problemTypeBaseURI.RUnlock()
+
+	problem := ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.GetMessage(),
+		Code:   err.GetCode(),
+		Info:   err.GetInfo(),
+	}
+
+	if processID := err.GetProcessID(); processID != "" {
+		problem.Instance = problemInstancePrefix + processID
+	}
+
+	if baseURI != "" && err.GetCode() != "" {
+		problem.Type = baseURI + err.GetCode()
+	}
+
+	if problemDebugEnabled.Load() {
+		problem.Debug = err.GetDebug()
+	}
+
+	return problem
+}
+
+// ToError generates new typego.Error from the problem details. The process id is restored from the instance generated
+// by NewProblemDetails
+func (p ProblemDetails) ToError() Error {
+	message := p.Detail
+	if message == "" {
+		message = p.Title
+	}
+
+	e := newErrorModel(p.Code, message, nil)
+	e.HttpStatus = p.Status
+
+	if processID, ok := strings.CutPrefix(p.Instance, problemInstancePrefix); ok {
+		e.ProcessID = processID
+	}
+
+	if len(p.Info) > 0 {
+		e.Info = append([]string(nil), p.Info...)
+	}
+
+	if len(p.Debug) > 0 {
+		e.Debug = append([]string(nil), p.Debug...)
+	}
+
+	return e
+}
+
+// WriteHTTP writes the typego.Error to the http response as `application/problem+json`
+func WriteHTTP(w http.ResponseWriter, err Error) {
+	WriteProblem(w, NewProblemDetails(err))
+}
+
+// WriteProblem writes the problem details to the http response as `application/problem+json`
+func WriteProblem(w http.ResponseWriter, problem ProblemDetails) {
+	status := problem.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	b, err := json.Marshal(problem)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	_, _ = w.Write(b)
+}
+
+// DecodeProblem decodes problem details from the reader
+func DecodeProblem(r io.Reader) (ProblemDetails, error) {
+	var problem ProblemDetails
+
+	if err := json.NewDecoder(r).Decode(&problem); err != nil {
+		return ProblemDetails{}, fmt.Errorf("typego: decode problem details: %w", err)
+	}
+
+	return problem, nil
+}
+
+// ErrorFromResponse generates new typego.Error from an `application/problem+json` http response. It returns nil
+// typego.Error if the response status is not an error status (below 400), and ErrNotProblem if the response has
+// another content type. The response body is consumed but not closed
+func ErrorFromResponse(resp *http.Response) (Error, error) {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != ProblemContentType {
+		return nil, ErrNotProblem
+	}
+
+	problem, err := DecodeProblem(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if problem.Status == 0 {
+		problem.Status = resp.StatusCode
+	}
+
+	return problem.ToError(), nil
+}
+
+// httpStatusOf gets the http status of the error, defaulting to 500
+func httpStatusOf(err Error) int {
+	if status := err.GetHttpStatus(); status != 0 {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
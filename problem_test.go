@@ -0,0 +1,184 @@
+package typego_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dalikewara/typego"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	problem := typego.NewProblemDetails(typego.NewError("01", "general error").AddInfo("raw info").AddDebug("raw debug"))
+
+	if problem.Type != "about:blank" {
+		log.Fatal("`problem.Type` must be `about:blank`")
+	}
+
+	if problem.Title != "Internal Server Error" {
+		log.Fatal("`problem.Title` must be `Internal Server Error`")
+	}
+
+	if problem.Status != 500 {
+		log.Fatal("`problem.Status` must be `500`")
+	}
+
+	if problem.Detail != "general error" {
+		log.Fatal("`problem.Detail` must be `general error`")
+	}
+
+	if problem.Code != "01" {
+		log.Fatal("`problem.Code` must be `01`")
+	}
+
+	if fmt.Sprintf("%v", problem.Info) != fmt.Sprintf("%v", []string{"raw info"}) {
+		log.Fatal("`problem.Info` must be `[]string{\"raw info\"}`")
+	}
+
+	if problem.Debug != nil {
+		log.Fatal("`problem.Debug` must nil")
+	}
+
+	if problem.Instance != "" {
+		log.Fatal("`problem.Instance` must be empty")
+	}
+
+	if problem := typego.NewProblemDetails(typego.NewError("01", "general error").SetProcessID("123")); problem.Instance != "urn:typego:process:123" {
+		log.Fatal("`problem.Instance` must be `urn:typego:process:123`")
+	}
+}
+
+func TestSetProblemDebug(t *testing.T) {
+	typego.SetProblemDebug(true)
+	defer typego.SetProblemDebug(false)
+
+	if problem := typego.NewProblemDetails(typego.NewError("01", "general error").AddDebug("raw debug")); fmt.Sprintf("%v", problem.Debug) != fmt.Sprintf("%v", []string{"raw debug"}) {
+		log.Fatal("`problem.Debug` must be `[]string{\"raw debug\"}`")
+	}
+}
+
+func TestSetProblemTypeBaseURI(t *testing.T) {
+	typego.SetProblemTypeBaseURI("https://example.com/problems/")
+	defer typego.SetProblemTypeBaseURI("")
+
+	if problem := typego.NewProblemDetails(typego.NewError("01", "general error")); problem.Type != "https://example.com/problems/01" {
+		log.Fatal("`problem.Type` must be `https://example.com/problems/01`")
+	}
+}
+
+func TestProblemDetails_ToError(t *testing.T) {
+	err := typego.ProblemDetails{Title: "Not Found", Status: 404, Code: "02", Info: []string{"raw info"}}.ToError()
+
+	if err.Error() != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"02\",\"message\":\"Not Found\",\"info\":[\"raw info\"],\"http_status\":404}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"02\",\"message\":\"Not Found\",\"info\":[\"raw info\"],\"http_status\":404}`")
+	}
+
+	if processID := typego.NewProblemDetails(typego.NewError("01", "").SetProcessID("123")).ToError().GetProcessID(); processID != "123" {
+		log.Fatal("`processID` must be `123`")
+	}
+}
+
+func TestWriteHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	typego.WriteHTTP(rec, typego.NewError("01", "not found").SetHttpStatus(404).AddInfo("raw info").AddDebug("raw debug"))
+
+	if rec.Code != 404 {
+		log.Fatal("`rec.Code` must be `404`")
+	}
+
+	if contentType := rec.Header().Get("Content-Type"); contentType != typego.ProblemContentType {
+		log.Fatal("`contentType` must be `application/problem+json`")
+	}
+
+	if body := rec.Body.String(); body != "{\"type\":\"about:blank\",\"title\":\"Not Found\",\"status\":404,\"detail\":\"not found\",\"code\":\"01\",\"info\":[\"raw info\"]}" {
+		log.Fatal("`body` must be `{\"type\":\"about:blank\",\"title\":\"Not Found\",\"status\":404,\"detail\":\"not found\",\"code\":\"01\",\"info\":[\"raw info\"]}`")
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	typego.WriteProblem(rec, typego.ProblemDetails{Title: "Internal Server Error"})
+
+	if rec.Code != 500 {
+		log.Fatal("`rec.Code` must be `500`")
+	}
+}
+
+func TestDecodeProblem(t *testing.T) {
+	t.Run("valid_format", func(t *testing.T) {
+		problem, err := typego.DecodeProblem(strings.NewReader("{\"type\":\"about:blank\",\"status\":400,\"detail\":\"bad request\",\"code\":\"01\"}"))
+		if err != nil {
+			log.Fatal("`err` must nil")
+		}
+
+		if problem.Status != 400 || problem.Detail != "bad request" || problem.Code != "01" {
+			log.Fatal("`problem` must be decoded")
+		}
+	})
+
+	t.Run("invalid_format", func(t *testing.T) {
+		if _, err := typego.DecodeProblem(strings.NewReader("bad request")); err == nil {
+			log.Fatal("`err` must not nil")
+		}
+	})
+}
+
+func TestErrorFromResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/problem":
+			typego.WriteHTTP(w, typego.NewError("01", "conflict").SetHttpStatus(409).AddInfo("raw info"))
+		case "/text":
+			http.Error(w, "bad request", 400)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("problem", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/problem")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		errResp, err := typego.ErrorFromResponse(resp)
+		if err != nil {
+			log.Fatal("`err` must nil")
+		}
+
+		if errResp.GetCode() != "01" || errResp.GetMessage() != "conflict" || errResp.GetHttpStatus() != 409 || len(errResp.GetInfo()) != 1 {
+			log.Fatal("`errResp` must be decoded")
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/text")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if _, err := typego.ErrorFromResponse(resp); !errors.Is(err, typego.ErrNotProblem) {
+			log.Fatal("`err` must be `typego.ErrNotProblem`")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if errResp, err := typego.ErrorFromResponse(resp); errResp != nil || err != nil {
+			log.Fatal("`errResp` and `err` must nil")
+		}
+	})
+}
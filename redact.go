@@ -0,0 +1,629 @@
+package typego
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// RedactedValue is the value that replaces a secret when the mask strategy is used
+const RedactedValue = "[REDACTED]"
+
+// RedactStrategy decides how a secret is redacted
+type RedactStrategy int
+
+const (
+	// RedactMask replaces the secret with RedactedValue
+	RedactMask RedactStrategy = iota
+
+	// RedactHash replaces the secret with its truncated SHA-256 hash, so equal secrets can still be correlated
+	RedactHash
+
+	// RedactDrop removes the secret. A redacted field or map key is omitted and a detected secret is removed from the
+	// string
+	RedactDrop
+)
+
+// Default redaction rules used by DefaultRedactor
+var (
+	DefaultRedactKeyPatterns = []string{
+		`password`, `passwd`, `secret`, `token`, `api[_-]?key`, `authorization`, `cookie`, `card[_-]?number`, `cvv`,
+		`ssn`, `private[_-]?key`,
+	}
+
+	DefaultRedactDetectors = []string{
+		`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`,
+		`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,
+		`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`,
+	}
+)
+
+var cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+var redactor atomic.Pointer[Redactor]
+
+// tagRedactor is used when no redactor is set. It has no rules, so it only masks the struct fields tagged with
+// `typego:"redact"`
+var tagRedactor = NewRedactor(RedactMask)
+
+// SetRedactor sets the redactor applied to the values added by AddInfo, AddDebug, AddField and AddFields after it is
+// set. There is no redactor by default, so the key patterns, detectors and card number detection are enabled by
+// setting one, such as DefaultRedactor(). The struct fields tagged with `typego:"redact"` are always masked, even
+// when the redactor is nil
+func SetRedactor(r *Redactor) {
+	redactor.Store(r)
+}
+
+// GetRedactor gets the redactor set by SetRedactor, or nil if there is none
+func GetRedactor() *Redactor {
+	return redactor.Load()
+}
+
+// activeRedactor gets the redactor applied to the added values, which masks at least the tagged struct fields
+func activeRedactor() *Redactor {
+	if r := redactor.Load(); r != nil {
+		return r
+	}
+
+	return tagRedactor
+}
+
+// Redactor redacts secrets and PII from values. A value is redacted when it is a struct field tagged with
+// `typego:"redact"`, when its key (struct field JSON name or map key) matches a key pattern, or when it is a string
+// that matches a detector
+type Redactor struct {
+	mu          sync.RWMutex
+	strategy    RedactStrategy
+	keys        []*regexp.Regexp
+	detectors   []*regexp.Regexp
+	cardNumbers bool
+}
+
+// NewRedactor generates new typego.Redactor without rules. It only redacts the struct fields tagged with
+// `typego:"redact"`
+func NewRedactor(strategy RedactStrategy) *Redactor {
+	return &Redactor{
+		strategy: strategy,
+	}
+}
+
+// DefaultRedactor generates new typego.Redactor using the mask strategy with DefaultRedactKeyPatterns,
+// DefaultRedactDetectors and card number detection
+func DefaultRedactor() *Redactor {
+	r := NewRedactor(RedactMask)
+
+	for _, pattern := range DefaultRedactKeyPatterns {
+		_ = r.AddKeyPattern(pattern)
+	}
+
+	for _, pattern := range DefaultRedactDetectors {
+		_ = r.AddDetector(pattern)
+	}
+
+	r.DetectCardNumbers(true)
+
+	return r
+}
+
+// AddKeyPattern adds a case-insensitive regular expression. The value of a struct field or map key matching the
+// pattern is redacted
+func (r *Redactor) AddKeyPattern(pattern string) error {
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys = append(r.keys, re)
+
+	return nil
+}
+
+// AddDetector adds a regular expression. The parts of a string matching the expression are redacted
+func (r *Redactor) AddDetector(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.detectors = append(r.detectors, re)
+
+	return nil
+}
+
+// DetectCardNumbers enables or disables the detection of payment card numbers (13 to 19 digits passing the Luhn
+// check) in strings
+func (r *Redactor) DetectCardNumbers(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cardNumbers = enabled
+}
+
+// Redact returns a redacted copy of the value that serializes to JSON like the original value. The original value is
+// never modified
+func (r *Redactor) Redact(value any) any {
+	if r == nil {
+		return value
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.redactValue(reflect.ValueOf(value), new(redactWalk))
+}
+
+// RedactString returns the string with the detected secrets redacted. A JSON object or array string is redacted by
+// its keys as well
+func (r *Redactor) RedactString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.redactString(s)
+}
+
+// RedactKey reports whether the value of the key must be redacted, and returns the redacted value
+func (r *Redactor) RedactKey(key string, value any) (any, bool) {
+	if r == nil {
+		return value, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.matchKey(key) {
+		return value, false
+	}
+
+	return r.secret(value), true
+}
+
+func (r *Redactor) redactString(s string) string {
+	// a string has no tagged fields, so there is nothing to redact without rules
+	if len(r.keys) == 0 && len(r.detectors) == 0 && !r.cardNumbers {
+		return s
+	}
+
+	trimmed := strings.TrimSpace(s)
+
+	if len(trimmed) > 1 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)) {
+		var v any
+
+		decoder := json.NewDecoder(strings.NewReader(trimmed))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&v); err == nil {
+			redacted, err := encodeRedacted(r.redactValue(reflect.ValueOf(v), new(redactWalk)))
+			if err != nil {
+				return s
+			}
+
+			if original, err := encodeRedacted(v); err == nil && original == redacted {
+				return s
+			}
+
+			return redacted
+		}
+	}
+
+	// the replacement allocates even without a match, so it is done only when the pattern matches
+	for _, re := range r.detectors {
+		if re.MatchString(s) {
+			s = re.ReplaceAllStringFunc(s, r.secretString)
+		}
+	}
+
+	if r.cardNumbers && cardNumberPattern.MatchString(s) {
+		s = cardNumberPattern.ReplaceAllStringFunc(s, func(match string) string {
+			if !luhn(match) {
+				return match
+			}
+
+			return r.secretString(match)
+		})
+	}
+
+	return s
+}
+
+// maxRedactDepth is the nesting depth from which a value is not redacted by its structure anymore
+const maxRedactDepth = 100
+
+// redactWalk tracks the nesting depth and the pointers, maps and slices being redacted, so a cyclic or too deep value
+// does not recurse forever
+type redactWalk struct {
+	depth   int
+	visited map[uintptr]struct{}
+}
+
+// enter reports whether the value can be walked into, and marks it as being walked. A value that cannot be walked into
+// is a cycle or is too deep
+func (w *redactWalk) enter(v reflect.Value) bool {
+	if w.depth >= maxRedactDepth {
+		return false
+	}
+
+	if isReference(v) {
+		if _, ok := w.visited[v.Pointer()]; ok {
+			return false
+		}
+
+		if w.visited == nil {
+			w.visited = make(map[uintptr]struct{})
+		}
+
+		w.visited[v.Pointer()] = struct{}{}
+	}
+
+	w.depth++
+
+	return true
+}
+
+// leave unmarks the value marked by enter
+func (w *redactWalk) leave(v reflect.Value) {
+	w.depth--
+
+	if isReference(v) {
+		delete(w.visited, v.Pointer())
+	}
+}
+
+// isReference reports whether the value is a non-empty pointer, map or slice, which can be part of a cycle
+func isReference(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		return !v.IsNil() && (v.Kind() == reflect.Pointer || v.Len() > 0)
+	}
+
+	return false
+}
+
+// isContainer reports whether the value holds other values
+func isContainer(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return true
+	}
+
+	return false
+}
+
+// unwalkedValue formats a cyclic or too deep value by its type, and its address if it is a pointer, map or slice. Its
+// content is not formatted, because fmt does not stop at a cycle either and would show the fields to redact
+func unwalkedValue(v reflect.Value) string {
+	if isReference(v) {
+		return fmt.Sprintf("%s(%#x)", v.Type(), v.Pointer())
+	}
+
+	return v.Type().String()
+}
+
+// redactValue redacts the value by its structure. A cyclic value, or a value nested deeper than maxRedactDepth, is
+// replaced by unwalkedValue
+func (r *Redactor) redactValue(v reflect.Value, w *redactWalk) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	if isContainer(v) {
+		if !w.enter(v) {
+			return unwalkedValue(v)
+		}
+
+		defer w.leave(v)
+	}
+
+	if v.Type() == jsonNumberType {
+		return v.Interface()
+	}
+
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return r.redactMarshaler(v.Interface(), w)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return r.redactValue(v.Elem(), w)
+	case reflect.String:
+		return r.redactString(v.String())
+	case reflect.Struct:
+		return r.redactStruct(v, w)
+	case reflect.Map:
+		return r.redactMap(v, w)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+
+		fallthrough
+	case reflect.Array:
+		values := make([]any, v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			values[i] = r.redactValue(v.Index(i), w)
+		}
+
+		return values
+	}
+
+	return v.Interface()
+}
+
+// redactMarshaler redacts a value having its own JSON or text encoding by redacting its encoded form
+func (r *Redactor) redactMarshaler(value any, w *redactWalk) any {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var v any
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&v); err != nil {
+		return value
+	}
+
+	return r.redactValue(reflect.ValueOf(v), w)
+}
+
+func (r *Redactor) redactStruct(v reflect.Value, w *redactWalk) any {
+	object := make(redactedObject, 0, v.NumField())
+
+	r.appendStructFields(&object, v, w)
+
+	return object
+}
+
+func (r *Redactor) appendStructFields(object *redactedObject, v reflect.Value, w *redactWalk) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				r.appendStructFields(object, embedded, w)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if strings.Contains(","+options+",", ",omitempty,") && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		if field.Tag.Get("typego") == "redact" || r.matchKey(name) {
+			if r.strategy == RedactDrop {
+				continue
+			}
+
+			*object = append(*object, redactedMember{key: name, value: r.secret(fieldValue.Interface())})
+
+			continue
+		}
+
+		*object = append(*object, redactedMember{key: name, value: r.redactValue(fieldValue, w)})
+	}
+}
+
+func (r *Redactor) redactMap(v reflect.Value, w *redactWalk) any {
+	if v.IsNil() {
+		return nil
+	}
+
+	m := make(map[string]any, v.Len())
+	iter := v.MapRange()
+
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+
+		if r.matchKey(key) {
+			if r.strategy != RedactDrop {
+				m[key] = r.secret(iter.Value().Interface())
+			}
+
+			continue
+		}
+
+		m[key] = r.redactValue(iter.Value(), w)
+	}
+
+	return m
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	for _, re := range r.keys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secret returns the redacted form of a whole value
+func (r *Redactor) secret(value any) any {
+	switch r.strategy {
+	case RedactHash:
+		s, ok := value.(string)
+		if !ok {
+			b, _ := json.Marshal(value)
+			s = string(b)
+		}
+
+		return hashSecret(s)
+	case RedactDrop:
+		return nil
+	}
+
+	return RedactedValue
+}
+
+// secretString returns the redacted form of a secret detected in a string
+func (r *Redactor) secretString(s string) string {
+	switch r.strategy {
+	case RedactHash:
+		return hashSecret(s)
+	case RedactDrop:
+		return ""
+	}
+
+	return RedactedValue
+}
+
+// encodeRedacted encodes the value to JSON without escaping HTML characters, so a redacted JSON string keeps its
+// original characters
+func encodeRedacted(value any) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func hashSecret(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// luhn reports whether the digits of s pass the Luhn check
+func luhn(s string) bool {
+	sum := 0
+	double := false
+
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+
+	return false
+}
+
+var (
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*interface{ MarshalText() ([]byte, error) })(nil)).Elem()
+)
+
+// redactedObject is a redacted struct that keeps the order of its fields when serialized
+type redactedObject []redactedMember
+
+type redactedMember struct {
+	key   string
+	value any
+}
+
+func (o redactedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, member := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(member.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(member.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,267 @@
+package typego_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"strings"
+	"testing"
+)
+
+type redactAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type redactUser struct {
+	redactAudit
+	Name     string `json:"name"`
+	PIN      string `json:"pin" typego:"redact"`
+	Password string `json:"password"`
+	Internal string `json:"-"`
+	Note     string `json:"note,omitempty"`
+	Card     string `json:"card"`
+}
+
+func TestSetRedactor(t *testing.T) {
+	defer typego.SetRedactor(nil)
+
+	if info := typego.NewError("01", "").AddInfo("mail: test@example.com").GetInfo(); info[0] != "mail: test@example.com" {
+		log.Fatal("`info[0]` must be `mail: test@example.com`")
+	}
+
+	typego.SetRedactor(typego.DefaultRedactor())
+
+	if info := typego.NewError("01", "").AddInfo("mail: test@example.com").GetInfo(); info[0] != "mail: [REDACTED]" {
+		log.Fatal("`info[0]` must be `mail: [REDACTED]`")
+	}
+}
+
+func TestSetRedactor_nilMasksTaggedFields(t *testing.T) {
+	typego.SetRedactor(nil)
+
+	user := redactUser{Name: "test", PIN: "1234", Password: "secret"}
+	err := typego.NewError("01", "").AddInfo(user).AddField("user", user)
+
+	if info := err.GetInfo(); info[0] != `{"created_by":"","name":"test","pin":"[REDACTED]","password":"secret","card":""}` {
+		log.Fatal("`info[0]` must mask only the tagged field, got `" + info[0] + "`")
+	}
+
+	if b, _ := json.Marshal(err.GetField("user")); string(b) != `{"created_by":"","name":"test","pin":"[REDACTED]","password":"secret","card":""}` {
+		log.Fatal("`user` must mask only the tagged field, got `" + string(b) + "`")
+	}
+
+	if info := typego.NewInfo().AddInfo("mail: test@example.com").GetInfo(); info[0] != "mail: test@example.com" {
+		log.Fatal("`info[0]` must not be redacted by the detectors")
+	}
+}
+
+func TestGetRedactor(t *testing.T) {
+	defer typego.SetRedactor(nil)
+
+	if r := typego.GetRedactor(); r != nil {
+		log.Fatal("`r` must be nil")
+	}
+
+	r := typego.DefaultRedactor()
+	typego.SetRedactor(r)
+
+	if redactor := typego.GetRedactor(); redactor != r {
+		log.Fatal("`redactor` must be `r`")
+	}
+}
+
+func TestDefaultRedactor(t *testing.T) {
+	defer typego.SetRedactor(nil)
+
+	typego.SetRedactor(typego.DefaultRedactor())
+
+	t.Run("struct", func(t *testing.T) {
+		user := redactUser{
+			redactAudit: redactAudit{CreatedBy: "admin"},
+			Name:        "test",
+			PIN:         "1234",
+			Password:    "secret",
+			Internal:    "internal",
+			Card:        "4111 1111 1111 1111",
+		}
+
+		info := typego.NewError("01", "general error").AddInfo(user).GetInfo()
+
+		if info[0] != "{\"created_by\":\"admin\",\"name\":\"test\",\"pin\":\"[REDACTED]\",\"password\":\"[REDACTED]\",\"card\":\"[REDACTED]\"}" {
+			log.Fatal("`info[0]` must be `{\"created_by\":\"admin\",\"name\":\"test\",\"pin\":\"[REDACTED]\",\"password\":\"[REDACTED]\",\"card\":\"[REDACTED]\"}`")
+		}
+
+		if user.PIN != "1234" {
+			log.Fatal("`user.PIN` must not modified")
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		if debug := typego.NewInfo().AddDebug(map[string]any{"api_key": "abc", "id": 1, "nested": map[string]string{"Authorization": "Basic abc"}}).GetDebug(); debug[0] != "{\"api_key\":\"[REDACTED]\",\"id\":1,\"nested\":{\"Authorization\":\"[REDACTED]\"}}" {
+			log.Fatal("`debug[0]` must be `{\"api_key\":\"[REDACTED]\",\"id\":1,\"nested\":{\"Authorization\":\"[REDACTED]\"}}`")
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		r := typego.DefaultRedactor()
+
+		if s := r.RedactString("header: Bearer abc.def-ghi"); s != "header: [REDACTED]" {
+			log.Fatal("`s` must be `header: [REDACTED]`")
+		}
+
+		if s := r.RedactString("card 4111-1111-1111-1111 and order 1234567890123"); s != "card [REDACTED] and order 1234567890123" {
+			log.Fatal("`s` must be `card [REDACTED] and order 1234567890123`")
+		}
+
+		if s := r.RedactString("{\"token\":\"abc\",\"id\":1}"); s != "{\"id\":1,\"token\":\"[REDACTED]\"}" {
+			log.Fatal("`s` must be `{\"id\":1,\"token\":\"[REDACTED]\"}`")
+		}
+
+		if s := r.RedactString("[1, 2]"); s != "[1, 2]" {
+			log.Fatal("`s` must be `[1, 2]`")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		if info := typego.NewInfo().AddInfo(errors.New("user test@example.com not found")).GetInfo(); info[0] != "user [REDACTED] not found" {
+			log.Fatal("`info[0]` must be `user [REDACTED] not found`")
+		}
+	})
+
+	t.Run("field", func(t *testing.T) {
+		err := typego.NewError("01", "").AddField("password", "secret").AddFields(map[string]any{"email": "test@example.com", "user": redactUser{Name: "test", PIN: "1234"}, "id": 1})
+
+		if password := err.GetField("password"); password != typego.RedactedValue {
+			log.Fatal("`password` must be `[REDACTED]`")
+		}
+
+		if email := err.GetField("email"); email != typego.RedactedValue {
+			log.Fatal("`email` must be `[REDACTED]`")
+		}
+
+		if id := err.GetField("id"); id != 1 {
+			log.Fatal("`id` must be `1`")
+		}
+
+		if b, _ := json.Marshal(err.GetField("user")); string(b) != "{\"created_by\":\"\",\"name\":\"test\",\"pin\":\"[REDACTED]\",\"password\":\"[REDACTED]\",\"card\":\"\"}" {
+			log.Fatal("`user` must be redacted")
+		}
+
+		if _, ok := typego.NewError("01", "").AddField("id", redactAudit{CreatedBy: "admin"}).GetField("id").(redactAudit); !ok {
+			log.Fatal("`id` must keep its type when nothing is redacted")
+		}
+	})
+}
+
+func TestNewRedactor(t *testing.T) {
+	t.Run("mask", func(t *testing.T) {
+		if v, _ := json.Marshal(typego.NewRedactor(typego.RedactMask).Redact(redactUser{Name: "test", PIN: "1234", Password: "secret"})); string(v) != "{\"created_by\":\"\",\"name\":\"test\",\"pin\":\"[REDACTED]\",\"password\":\"secret\",\"card\":\"\"}" {
+			log.Fatal("`v` must redact the tagged field only")
+		}
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		r := typego.NewRedactor(typego.RedactHash)
+		_ = r.AddDetector(`\d{4}`)
+
+		s := r.RedactString("pin 1234")
+
+		if !strings.HasPrefix(s, "pin sha256:") || len(s) != len("pin sha256:")+16 {
+			log.Fatal("`s` must be `pin sha256:<hash>`")
+		}
+
+		if s != r.RedactString("pin 1234") {
+			log.Fatal("`s` must be deterministic")
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		r := typego.NewRedactor(typego.RedactDrop)
+		_ = r.AddKeyPattern(`^password$`)
+
+		if v, _ := json.Marshal(r.Redact(map[string]string{"password": "secret", "name": "test"})); string(v) != "{\"name\":\"test\"}" {
+			log.Fatal("`v` must be `{\"name\":\"test\"}`")
+		}
+
+		if v, _ := json.Marshal(r.Redact(redactUser{Name: "test", PIN: "1234"})); string(v) != "{\"created_by\":\"\",\"name\":\"test\",\"card\":\"\"}" {
+			log.Fatal("`v` must be `{\"created_by\":\"\",\"name\":\"test\",\"card\":\"\"}`")
+		}
+	})
+}
+
+type redactNode struct {
+	Name     string      `json:"name"`
+	Password string      `json:"password"`
+	Next     *redactNode `json:"next"`
+}
+
+func TestRedactor_Redact_cycle(t *testing.T) {
+	n := &redactNode{Name: "a", Password: "secret"}
+	n.Next = n
+
+	b, err := json.Marshal(typego.DefaultRedactor().Redact(n))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if s := string(b); !strings.HasPrefix(s, `{"name":"a","password":"[REDACTED]","next":"*typego_test.redactNode(0x`) {
+		log.Fatal("`s` must end the cycle at `next`, got `" + s + "`")
+	}
+
+	m := map[string]any{"id": 1}
+	m["self"] = m
+
+	if b, err := json.Marshal(typego.DefaultRedactor().Redact(m)); err != nil || !strings.Contains(string(b), `"self":"map[string]interface {}(0x`) {
+		log.Fatal("`m` must end the cycle at `self`, got `" + string(b) + "`")
+	}
+
+	var deep any = "leaf"
+
+	for i := 0; i < 200; i++ {
+		deep = []any{deep}
+	}
+
+	if b, err := json.Marshal(typego.DefaultRedactor().Redact(deep)); err != nil || strings.Contains(string(b), "leaf") {
+		log.Fatal("`deep` must be cut at the max depth")
+	}
+
+	defer typego.SetRedactor(nil)
+
+	typego.SetRedactor(typego.DefaultRedactor())
+
+	if info := typego.NewError("01", "").AddInfo(n).GetInfo(); !strings.HasPrefix(info[0], `{"name":"a","password":"[REDACTED]","next":`) {
+		log.Fatal("`info[0]` must be redacted, got `" + info[0] + "`")
+	}
+}
+
+func TestRedactor_AddKeyPattern(t *testing.T) {
+	if err := typego.NewRedactor(typego.RedactMask).AddKeyPattern("("); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestRedactor_AddDetector(t *testing.T) {
+	if err := typego.NewRedactor(typego.RedactMask).AddDetector("("); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestRedactor_DetectCardNumbers(t *testing.T) {
+	r := typego.DefaultRedactor()
+	r.DetectCardNumbers(false)
+
+	if s := r.RedactString("4111111111111111"); s != "4111111111111111" {
+		log.Fatal("`s` must be `4111111111111111`")
+	}
+}
+
+func TestRedactor_RedactKey(t *testing.T) {
+	if v, ok := typego.DefaultRedactor().RedactKey("client_secret", "abc"); !ok || v != typego.RedactedValue {
+		log.Fatal("`v` must be `[REDACTED]`")
+	}
+
+	if v, ok := typego.DefaultRedactor().RedactKey("name", "abc"); ok || v != "abc" {
+		log.Fatal("`v` must be `abc`")
+	}
+}
@@ -0,0 +1,128 @@
+package typego
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrDuplicateCode is returned when registering an error code that is already registered
+var ErrDuplicateCode = errors.New("typego: duplicate error code")
+
+// ErrEmptyCode is returned when registering an error definition without code
+var ErrEmptyCode = errors.New("typego: empty error code")
+
+// ErrorDefinition declares the default values of an error code
+type ErrorDefinition struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	HttpStatus int    `json:"http_status,omitempty"`
+	RPCStatus  int    `json:"rpc_status,omitempty"`
+	Level      string `json:"level,omitempty"`
+}
+
+var registry = struct {
+	sync.RWMutex
+	definitions map[string]ErrorDefinition
+}{
+	definitions: make(map[string]ErrorDefinition),
+}
+
+// Register registers the error definitions. It returns ErrDuplicateCode if a code is already registered and
+// ErrEmptyCode if a code is empty, in which case none of the definitions is registered
+func Register(definitions ...ErrorDefinition) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	seen := make(map[string]struct{}, len(definitions))
+
+	for _, definition := range definitions {
+		if definition.Code == "" {
+			return ErrEmptyCode
+		}
+
+		if _, ok := registry.definitions[definition.Code]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateCode, definition.Code)
+		}
+
+		if _, ok := seen[definition.Code]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateCode, definition.Code)
+		}
+
+		seen[definition.Code] = struct{}{}
+	}
+
+	for _, definition := range definitions {
+		if definition.Level == "" {
+			definition.Level = LevelError
+		}
+
+		registry.definitions[definition.Code] = definition
+	}
+
+	return nil
+}
+
+// MustRegister is like Register but panics if the definitions cannot be registered. It is intended to be used in
+// package level variable initialization or init functions
+func MustRegister(definitions ...ErrorDefinition) {
+	if err := Register(definitions...); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes the error definition of the code from the registry
+func Unregister(code string) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	delete(registry.definitions, code)
+}
+
+// Lookup gets the registered error definition of the code
+func Lookup(code string) (ErrorDefinition, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	definition, ok := registry.definitions[code]
+
+	return definition, ok
+}
+
+// Definitions returns all registered error definitions sorted by code
+func Definitions() []ErrorDefinition {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	definitions := make([]ErrorDefinition, 0, len(registry.definitions))
+
+	for _, definition := range registry.definitions {
+		definitions = append(definitions, definition)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Code < definitions[j].Code
+	})
+
+	return definitions
+}
+
+// FromCode generates new typego.Error from the registered error definition of the code. If the code is not
+// registered, it generates new typego.Error with the code only
+func FromCode(code string) Error {
+	definition, ok := Lookup(code)
+	if !ok {
+		definition = ErrorDefinition{
+			Code:  code,
+			Level: LevelError,
+		}
+	}
+
+	e := newErrorModel(definition.Code, definition.Message, nil)
+	e.Level = definition.Level
+	e.HttpStatus = definition.HttpStatus
+	e.RPCStatus = definition.RPCStatus
+
+	return e
+}
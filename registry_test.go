@@ -0,0 +1,114 @@
+package typego_test
+
+import (
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	defer typego.Unregister("REG-01")
+	defer typego.Unregister("REG-02")
+
+	if err := typego.Register(typego.ErrorDefinition{Code: "REG-01", Message: "registry error"}); err != nil {
+		log.Fatal("`err` must nil")
+	}
+
+	if err := typego.Register(typego.ErrorDefinition{Code: "REG-01", Message: "registry error"}); !errors.Is(err, typego.ErrDuplicateCode) {
+		log.Fatal("`err` must be `typego.ErrDuplicateCode`")
+	}
+
+	if err := typego.Register(typego.ErrorDefinition{Code: "REG-02"}, typego.ErrorDefinition{Code: "REG-02"}); !errors.Is(err, typego.ErrDuplicateCode) {
+		log.Fatal("`err` must be `typego.ErrDuplicateCode`")
+	}
+
+	if _, ok := typego.Lookup("REG-02"); ok {
+		log.Fatal("`REG-02` must not registered")
+	}
+
+	if err := typego.Register(typego.ErrorDefinition{}); !errors.Is(err, typego.ErrEmptyCode) {
+		log.Fatal("`err` must be `typego.ErrEmptyCode`")
+	}
+}
+
+func TestMustRegister(t *testing.T) {
+	defer typego.Unregister("REG-03")
+
+	typego.MustRegister(typego.ErrorDefinition{Code: "REG-03"})
+
+	defer func() {
+		if r := recover(); r == nil {
+			log.Fatal("`MustRegister` must panic")
+		}
+	}()
+
+	typego.MustRegister(typego.ErrorDefinition{Code: "REG-03"})
+}
+
+func TestUnregister(t *testing.T) {
+	typego.MustRegister(typego.ErrorDefinition{Code: "REG-04"})
+	typego.Unregister("REG-04")
+
+	if _, ok := typego.Lookup("REG-04"); ok {
+		log.Fatal("`REG-04` must not registered")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	defer typego.Unregister("REG-05")
+
+	typego.MustRegister(typego.ErrorDefinition{Code: "REG-05", Message: "registry error", HttpStatus: 400})
+
+	definition, ok := typego.Lookup("REG-05")
+	if !ok {
+		log.Fatal("`REG-05` must registered")
+	}
+
+	if definition.Level != "error" {
+		log.Fatal("`definition.Level` must be `error`")
+	}
+
+	if definition.HttpStatus != 400 {
+		log.Fatal("`definition.HttpStatus` must be `400`")
+	}
+}
+
+func TestDefinitions(t *testing.T) {
+	defer typego.Unregister("REG-07")
+	defer typego.Unregister("REG-06")
+
+	typego.MustRegister(typego.ErrorDefinition{Code: "REG-07"}, typego.ErrorDefinition{Code: "REG-06"})
+
+	definitions := typego.Definitions()
+
+	if definitionsLen := len(definitions); definitionsLen != 2 {
+		log.Fatal("`definitionsLen` must be `2`")
+	}
+
+	if definitions[0].Code != "REG-06" || definitions[1].Code != "REG-07" {
+		log.Fatal("`definitions` must be sorted by code")
+	}
+}
+
+func TestFromCode(t *testing.T) {
+	defer typego.Unregister("AUTH-01")
+
+	typego.MustRegister(typego.ErrorDefinition{
+		Code:       "AUTH-01",
+		Message:    "unauthorized",
+		HttpStatus: 401,
+		RPCStatus:  16,
+		Level:      "warning",
+	})
+
+	err := typego.FromCode("AUTH-01")
+
+	if err.Error() != "{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"AUTH-01\",\"message\":\"unauthorized\",\"info\":null,\"http_status\":401,\"rpc_status\":16}" {
+		log.Fatal("`err` must be `{\"level\":\"warning\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"AUTH-01\",\"message\":\"unauthorized\",\"info\":null,\"http_status\":401,\"rpc_status\":16}`")
+	}
+
+	if err := typego.FromCode("AUTH-99"); err.Error() != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"AUTH-99\",\"message\":\"\",\"info\":null}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"AUTH-99\",\"message\":\"\",\"info\":null}`")
+	}
+}
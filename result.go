@@ -0,0 +1,190 @@
+package typego
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResultContentType is the media type of the result envelope
+const ResultContentType = "application/json"
+
+// ErrNotResult is returned when decoding a document that is not a result envelope
+var ErrNotResult = errors.New("typego: document is not a result envelope")
+
+type Result[T any] interface {
+	// IsOk reports whether the result has success data
+	IsOk() bool
+
+	// GetData gets the success data, or the zero value if the result failed
+	GetData() T
+
+	// GetError gets the error, or nil if the result succeeded
+	GetError() Error
+
+	// Get gets the success data and the error. The error is nil if the result succeeded
+	Get() (T, error)
+
+	// GetHttpStatus gets 200 if the result succeeded, otherwise, the http status of the error, or 500 if the error has no
+	// http status
+	GetHttpStatus() int
+
+	// WriteHTTP writes the result envelope to the http response as `application/json` with the http status of the
+	// result
+	WriteHTTP(w http.ResponseWriter)
+
+	// MarshalJSON encodes the result in the envelope, which is `{"data":...}` if the result succeeded, otherwise,
+	// `{"error":...}`. The envelope is sent to the clients, so the error is always encoded as its public JSON
+	MarshalJSON() ([]byte, error)
+}
+
+// resultEnvelope is the JSON envelope of typego.Result
+type resultEnvelope struct {
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error json.RawMessage `json:"error,omitempty"`
+}
+
+type resultModel[T any] struct {
+	data T
+	err  Error
+}
+
+func (r resultModel[T]) IsOk() bool {
+	return r.err == nil
+}
+
+func (r resultModel[T]) GetData() T {
+	return r.data
+}
+
+func (r resultModel[T]) GetError() Error {
+	return r.err
+}
+
+func (r resultModel[T]) Get() (T, error) {
+	if r.err == nil {
+		return r.data, nil
+	}
+
+	return r.data, r.err
+}
+
+func (r resultModel[T]) GetHttpStatus() int {
+	if r.err == nil {
+		return http.StatusOK
+	}
+
+	return httpStatusOf(r.err)
+}
+
+func (r resultModel[T]) WriteHTTP(w http.ResponseWriter) {
+	b, err := r.MarshalJSON()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ResultContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(r.GetHttpStatus())
+
+	_, _ = w.Write(b)
+}
+
+func (r resultModel[T]) MarshalJSON() ([]byte, error) {
+	var (
+		envelope resultEnvelope
+		err      error
+	)
+
+	if r.err == nil {
+		if envelope.Data, err = json.Marshal(r.data); err != nil {
+			return nil, err
+		}
+	} else {
+		envelope.Error = json.RawMessage(r.err.PublicJSON())
+	}
+
+	return json.Marshal(envelope)
+}
+
+// Ok generates new typego.Result with the success data
+func Ok[T any](data T) Result[T] {
+	return resultModel[T]{
+		data: data,
+	}
+}
+
+// Fail generates new typego.Result with the error. A nil error is replaced by an error with the default error code
+// wrapping ErrNilError, so the result still fails
+func Fail[T any](err Error) Result[T] {
+	if err == nil {
+		err = NewErrorFromError(ErrNilError)
+	}
+
+	return resultModel[T]{
+		err: err,
+	}
+}
+
+// Map generates new typego.Result with the success data converted by fn. A failed result keeps its error, and fn is
+// not called
+func Map[T any, U any](result Result[T], fn func(data T) U) Result[U] {
+	if !result.IsOk() {
+		return Fail[U](result.GetError())
+	}
+
+	return Ok(fn(result.GetData()))
+}
+
+// Then returns the result of fn called with the success data. A failed result keeps its error, and fn is not called
+func Then[T any, U any](result Result[T], fn func(data T) Result[U]) Result[U] {
+	if !result.IsOk() {
+		return Fail[U](result.GetError())
+	}
+
+	return fn(result.GetData())
+}
+
+// DecodeResult decodes typego.Result from the result envelope in the reader. The error has only the members of the
+// public JSON, and its level is `error`. It returns ErrNotResult if the document has neither data nor error, and
+// ErrInvalidFormat if the error has no code
+func DecodeResult[T any](r io.Reader) (Result[T], error) {
+	var envelope resultEnvelope
+
+	if err := json.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("typego: decode result: %w", err)
+	}
+
+	if len(envelope.Error) > 0 && string(envelope.Error) != "null" {
+		var e errorModel
+
+		if err := json.Unmarshal(envelope.Error, &e); err != nil {
+			return nil, fmt.Errorf("typego: decode result: %w: %w", ErrInvalidFormat, err)
+		}
+
+		if e.Code == "" {
+			return nil, fmt.Errorf("typego: decode result: %w: missing code", ErrInvalidFormat)
+		}
+
+		if e.Level == "" {
+			e.Level = LevelError
+		}
+
+		return Fail[T](&e), nil
+	}
+
+	if len(envelope.Data) == 0 {
+		return nil, ErrNotResult
+	}
+
+	var data T
+
+	if err := json.Unmarshal(envelope.Data, &data); err != nil {
+		return nil, fmt.Errorf("typego: decode result: %w", err)
+	}
+
+	return Ok(data), nil
+}
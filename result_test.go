@@ -0,0 +1,214 @@
+package typego_test
+
+import (
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type resultUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestOk(t *testing.T) {
+	result := typego.Ok(resultUser{ID: 1, Name: "test"})
+
+	if !result.IsOk() {
+		log.Fatal("`result.IsOk()` must be true")
+	}
+
+	if result.GetError() != nil {
+		log.Fatal("`result.GetError()` must be nil")
+	}
+
+	data, err := result.Get()
+	if err != nil {
+		log.Fatal("`err` must be nil")
+	}
+
+	if data.Name != "test" || result.GetData().ID != 1 {
+		log.Fatal("`data` must be `{1 test}`")
+	}
+
+	if status := result.GetHttpStatus(); status != 200 {
+		log.Fatal("`status` must be `200`")
+	}
+
+	b, err := result.MarshalJSON()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if string(b) != `{"data":{"id":1,"name":"test"}}` {
+		log.Fatal("`b` must be `{\"data\":{\"id\":1,\"name\":\"test\"}}`")
+	}
+
+	if b, _ = typego.Ok[*resultUser](nil).MarshalJSON(); string(b) != `{"data":null}` {
+		log.Fatal("`b` must be `{\"data\":null}`")
+	}
+}
+
+func TestFail(t *testing.T) {
+	result := typego.Fail[resultUser](typego.NewError("01", "not found").SetHttpStatus(404))
+
+	if result.IsOk() {
+		log.Fatal("`result.IsOk()` must be false")
+	}
+
+	if data, err := result.Get(); err == nil || data.ID != 0 {
+		log.Fatal("`err` must not nil")
+	}
+
+	if code := result.GetError().GetCode(); code != "01" {
+		log.Fatal("`code` must be `01`")
+	}
+
+	if status := result.GetHttpStatus(); status != 404 {
+		log.Fatal("`status` must be `404`")
+	}
+
+	if b, _ := result.MarshalJSON(); string(b) != `{"error":{"code":"01","message":"not found","info":null}}` {
+		log.Fatal("`b` must be `{\"error\":{\"code\":\"01\",\"message\":\"not found\",\"info\":null}}`")
+	}
+
+	internal := typego.Fail[int](typego.NewError("01", "not found").SetDeveloperMessage("database timeout").AddDebug("debug").AddField("user_id", 1).WithStack())
+
+	if b, _ := internal.MarshalJSON(); string(b) != `{"error":{"code":"01","message":"not found","info":null}}` {
+		log.Fatal("`b` must not contain the developer message, fields, debug and stack")
+	}
+}
+
+func TestFail_nilError(t *testing.T) {
+	result := typego.Fail[int](nil)
+
+	if result.IsOk() {
+		log.Fatal("`result.IsOk()` must be false")
+	}
+
+	if code := result.GetError().GetCode(); code != typego.GetDefaultErrorCode() {
+		log.Fatal("`code` must be the default error code")
+	}
+
+	if _, err := result.Get(); !errors.Is(err, typego.ErrNilError) {
+		log.Fatal("`err` must wrap `typego.ErrNilError`")
+	}
+}
+
+func TestMap(t *testing.T) {
+	result := typego.Map(typego.Ok(1), strconv.Itoa)
+
+	if data := result.GetData(); data != "1" {
+		log.Fatal("`data` must be `1`")
+	}
+
+	called := false
+
+	failed := typego.Map(typego.Fail[int](typego.NewError("01", "general error")), func(data int) string {
+		called = true
+		return strconv.Itoa(data)
+	})
+
+	if called {
+		log.Fatal("`called` must be false")
+	}
+
+	if code := failed.GetError().GetCode(); code != "01" {
+		log.Fatal("`code` must be `01`")
+	}
+}
+
+func TestThen(t *testing.T) {
+	parse := func(s string) typego.Result[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return typego.Fail[int](typego.NewError("02", "invalid number").SetHttpStatus(400))
+		}
+
+		return typego.Ok(n)
+	}
+
+	if data := typego.Then(typego.Ok("12"), parse).GetData(); data != 12 {
+		log.Fatal("`data` must be `12`")
+	}
+
+	if code := typego.Then(typego.Ok("x"), parse).GetError().GetCode(); code != "02" {
+		log.Fatal("`code` must be `02`")
+	}
+
+	if code := typego.Then(typego.Fail[string](typego.NewError("01", "general error")), parse).GetError().GetCode(); code != "01" {
+		log.Fatal("`code` must be `01`")
+	}
+}
+
+func TestResult_WriteHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	typego.Ok(resultUser{ID: 1, Name: "test"}).WriteHTTP(rec)
+
+	if rec.Code != 200 {
+		log.Fatal("`rec.Code` must be `200`")
+	}
+
+	if contentType := rec.Header().Get("Content-Type"); contentType != typego.ResultContentType {
+		log.Fatal("`contentType` must be `application/json`")
+	}
+
+	if body := rec.Body.String(); body != `{"data":{"id":1,"name":"test"}}` {
+		log.Fatal("`body` must be `{\"data\":{\"id\":1,\"name\":\"test\"}}`")
+	}
+
+	rec = httptest.NewRecorder()
+
+	typego.Fail[resultUser](typego.NewError("01", "general error")).WriteHTTP(rec)
+
+	if rec.Code != 500 {
+		log.Fatal("`rec.Code` must be `500`")
+	}
+
+	if body := rec.Body.String(); body != `{"error":{"code":"01","message":"general error","info":null}}` {
+		log.Fatal("`body` must be `{\"error\":{\"code\":\"01\",\"message\":\"general error\",\"info\":null}}`")
+	}
+}
+
+func TestDecodeResult(t *testing.T) {
+	result, err := typego.DecodeResult[resultUser](strings.NewReader(`{"data":{"id":1,"name":"test"}}`))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if data := result.GetData(); data.ID != 1 || data.Name != "test" {
+		log.Fatal("`data` must be `{1 test}`")
+	}
+
+	b, _ := typego.Fail[resultUser](typego.NewError("01", "not found").SetHttpStatus(404).AddInfo("raw info")).MarshalJSON()
+
+	result, err = typego.DecodeResult[resultUser](strings.NewReader(string(b)))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if errString := result.GetError().Error(); errString != `{"level":"error","code":"01","message":"not found","info":["raw info"]}` {
+		log.Fatal("`errString` must be `{\"level\":\"error\",\"code\":\"01\",\"message\":\"not found\",\"info\":[\"raw info\"]}`")
+	}
+
+	if result, err = typego.DecodeResult[resultUser](strings.NewReader(`{"error":{"code":"01","message":"not found","info":null}}`)); err != nil || result.GetError().GetLevel() != typego.LevelError {
+		log.Fatal("`result.GetError().GetLevel()` must be `error`")
+	}
+
+	if _, err = typego.DecodeResult[resultUser](strings.NewReader(`{}`)); !errors.Is(err, typego.ErrNotResult) {
+		log.Fatal("`err` must be `typego.ErrNotResult`")
+	}
+
+	if _, err = typego.DecodeResult[resultUser](strings.NewReader(`{"error":{"message":"not found"}}`)); !errors.Is(err, typego.ErrInvalidFormat) {
+		log.Fatal("`err` must be `typego.ErrInvalidFormat`")
+	}
+
+	if _, err = typego.DecodeResult[resultUser](strings.NewReader(`{"data":`)); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
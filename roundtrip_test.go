@@ -0,0 +1,192 @@
+package typego_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func fullErrorData(level string) typego.ErrorData {
+	return typego.ErrorData{
+		Level:            level,
+		Timestamp:        typego.NewTimestamp(testTime),
+		ProcessID:        "123",
+		ProcessName:      "test",
+		Code:             "01",
+		Message:          "general error",
+		DeveloperMessage: "database timeout",
+		Info:             []string{"raw info"},
+		Fields:           map[string]any{"name": "test", "paid": true, "tags": []any{"a", "b"}, "user_id": json.Number("9007199254740993")},
+		HttpStatus:       500,
+		RPCStatus:        13,
+		Debug:            []string{"raw debug"},
+		Stack:            []string{"/app/main.go:12 main.main"},
+		Suppressed:       3,
+	}
+}
+
+func TestErrorFromData(t *testing.T) {
+	data := fullErrorData(typego.LevelWarning)
+	err := typego.ErrorFromData(data)
+
+	data.Info[0] = "changed"
+
+	if errInfo := err.GetInfo()[0]; errInfo != "raw info" {
+		log.Fatal("`errInfo` must be `raw info`")
+	}
+
+	if errLevel := typego.ErrorFromData(typego.ErrorData{Code: "01"}).GetLevel(); errLevel != typego.LevelError {
+		log.Fatal("`errLevel` must be `error`")
+	}
+}
+
+func TestInfoFromData(t *testing.T) {
+	if infoLevel := typego.InfoFromData(typego.InfoData{}).GetLevel(); infoLevel != typego.LevelInfo {
+		log.Fatal("`infoLevel` must be `info`")
+	}
+
+	if infoLevel := typego.InfoFromData(typego.InfoData{Level: typego.LevelNotice}).GetLevel(); infoLevel != typego.LevelNotice {
+		log.Fatal("`infoLevel` must be `notice`")
+	}
+}
+
+func TestErrorModel_Data(t *testing.T) {
+	err := typego.NewError("01", "general error").AddInfo("raw info")
+
+	data := err.Data()
+	data.Info[0] = "changed"
+
+	if errInfo := err.GetInfo()[0]; errInfo != "raw info" {
+		log.Fatal("`errInfo` must be `raw info`")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		for _, level := range []string{typego.LevelDebug, typego.LevelInfo, typego.LevelNotice, typego.LevelWarning, typego.LevelError, typego.LevelFatal} {
+			err := typego.ErrorFromData(fullErrorData(level))
+
+			decoded := typego.NewErrorFromError(errors.New(err.Error()))
+
+			if !reflect.DeepEqual(decoded.Data(), err.Data()) {
+				log.Fatal("`decoded` must be equal to `err` with level " + level)
+			}
+		}
+	})
+
+	t.Run("error_minimal", func(t *testing.T) {
+		err := typego.NewError("01", "")
+
+		if decoded := typego.NewErrorFromError(errors.New(err.Error())); !reflect.DeepEqual(decoded.Data(), err.Data()) {
+			log.Fatal("`decoded` must be equal to `err`")
+		}
+
+		if decoded := typego.NewErrorFromError(errors.New(err.Error())); decoded.GetInfo() != nil {
+			log.Fatal("`decoded.GetInfo()` must nil")
+		}
+	})
+
+	t.Run("error_data", func(t *testing.T) {
+		b, err := json.Marshal(typego.ErrorFromData(fullErrorData(typego.LevelError)))
+		if err != nil {
+			log.Fatal("`err` must nil")
+		}
+
+		var data typego.ErrorData
+
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+
+		if err = decoder.Decode(&data); err != nil {
+			log.Fatal("`err` must nil")
+		}
+
+		if !reflect.DeepEqual(data, fullErrorData(typego.LevelError)) {
+			log.Fatal("`data` must be equal to the encoded data")
+		}
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		type response struct {
+			typego.ErrorData
+			RequestID string `json:"request_id"`
+		}
+
+		b, _ := json.Marshal(response{ErrorData: typego.NewError("01", "general error").Data(), RequestID: "123"})
+
+		if string(b) != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"request_id\":\"123\"}" {
+			log.Fatal("`b` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":null,\"request_id\":\"123\"}`")
+		}
+	})
+
+	t.Run("info", func(t *testing.T) {
+		for _, info := range []typego.Info{typego.NewInfo(), typego.NewDebugEntry(), typego.NewNotice(), typego.NewWarning(), typego.NewFatal()} {
+			info = info.SetProcessID("123").SetProcessName("test").AddInfo("raw info").AddField("user_id", json.Number("1")).AddDebug("raw debug").Finish()
+
+			var data typego.InfoData
+
+			decoder := json.NewDecoder(strings.NewReader(info.String()))
+			decoder.UseNumber()
+
+			if err := decoder.Decode(&data); err != nil {
+				log.Fatal("`err` must nil")
+			}
+
+			if decoded := typego.InfoFromData(data); !reflect.DeepEqual(decoded.Data(), info.Data()) {
+				log.Fatal("`decoded` must be equal to `info` with level " + info.GetLevel())
+			}
+		}
+	})
+}
+
+func TestNewError_comparable(t *testing.T) {
+	errNotFound := typego.NewError("01", "not found")
+
+	var err error = errNotFound
+
+	if err != errNotFound {
+		log.Fatal("`err` must be `errNotFound`")
+	}
+
+	if err == error(typego.NewError("01", "not found")) {
+		log.Fatal("`err` must not be a new error with the same code")
+	}
+
+	if err == error(errNotFound.AddInfo("raw info")) {
+		log.Fatal("`err` must not be the changed error")
+	}
+
+	if changed := errNotFound.AddInfo("raw info"); changed == changed.AddInfo("raw info 2") {
+		log.Fatal("`changed` must not be the changed error")
+	}
+
+	if fromData := typego.ErrorFromData(fullErrorData(typego.LevelError)); fromData != fromData {
+		log.Fatal("`fromData` must be itself")
+	}
+
+	info := typego.NewInfo()
+
+	if info != info || info == info.AddInfo("raw info") {
+		log.Fatal("`info` must be comparable")
+	}
+}
+
+func TestNewErrorFromError_nonJSONEncoder(t *testing.T) {
+	defer typego.SetEncoder(nil)
+
+	for _, enc := range []typego.Encoder{typego.NewLogfmtEncoder(), typego.NewTextEncoder()} {
+		typego.SetEncoder(enc)
+
+		err := typego.ErrorFromData(fullErrorData(typego.LevelWarning))
+		parsed := typego.NewErrorFromError(errors.New(err.Error()))
+
+		if !reflect.DeepEqual(parsed.Data(), err.Data()) {
+			log.Fatal("`parsed.Data()` must be `err.Data()`")
+		}
+	}
+}
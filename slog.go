@@ -0,0 +1,509 @@
+package typego
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// slog levels of typego levels that have no slog counterpart
+const (
+	slogLevelNotice = slog.Level(2)
+	slogLevelFatal  = slog.Level(12)
+)
+
+func (e *errorModel) LogValue() slog.Value {
+	return slog.GroupValue(errorAttrs(e, true)...)
+}
+
+func (i infoModel) LogValue() slog.Value {
+	return slog.GroupValue(infoAttrs(i, true)...)
+}
+
+// NewSlogErrorLog generates typego.ErrorLogHandler that forwards the error to the slog logger. It can be used by
+// SetCustomErrorLog. The record time is the error timestamp
+func NewSlogErrorLog(logger *slog.Logger) ErrorLogHandler {
+	return func(err Error) {
+		logSlog(logger, err.GetTimestamp(), slogLevel(err.GetLevel()), err.GetMessage(), errorAttrs(err, false))
+	}
+}
+
+// NewSlogInfoLog generates typego.InfoLogHandler that forwards the information to the slog logger. It can be used by
+// SetCustomInfoLog. The record time is the information timestamp
+func NewSlogInfoLog(logger *slog.Logger) InfoLogHandler {
+	return func(info Info) {
+		logSlog(logger, info.GetTimestamp(), slogLevel(info.GetLevel()), "", infoAttrs(info, false))
+	}
+}
+
+// logSlog logs a record with the given time, so the record keeps the time of the entry instead of the time it is
+// forwarded. A zero time is replaced by the current time
+func logSlog(logger *slog.Logger, t time.Time, level slog.Level, message string, attrs []slog.Attr) {
+	ctx := context.Background()
+
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	if t.IsZero() {
+		t = now()
+	}
+
+	r := slog.NewRecord(t, level, message, 0)
+	r.AddAttrs(attrs...)
+
+	_ = logger.Handler().Handle(ctx, r)
+}
+
+// NewSlogHandler generates new slog.Handler that writes the records in the typego JSON shape. Record attributes named
+// `process_id`, `process_name`, `code`, `http_status` and `rpc_status` fill the corresponding members, typego.Error
+// attributes are merged into the record, and any other attribute is added to the fields. Attributes inside groups are
+// added to the fields with dotted keys, such as `req.method`
+func NewSlogHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	h := &slogHandler{
+		w:  w,
+		mu: &sync.Mutex{},
+	}
+
+	if opts != nil {
+		h.opts = *opts
+	}
+
+	return h
+}
+
+type slogRecord struct {
+	Level       string         `json:"level"`
+	Timestamp   Timestamp      `json:"timestamp,omitempty"`
+	ProcessID   string         `json:"process_id,omitempty"`
+	ProcessName string         `json:"process_name,omitempty"`
+	Code        string         `json:"code,omitempty"`
+	Message     string         `json:"message,omitempty"`
+	DevMessage  string         `json:"developer_message,omitempty"`
+	Info        []string       `json:"info"`
+	Fields      map[string]any `json:"fields,omitempty"`
+	HttpStatus  int            `json:"http_status,omitempty"`
+	RPCStatus   int            `json:"rpc_status,omitempty"`
+	Debug       []string       `json:"debug,omitempty"`
+	Stack       []string       `json:"stack,omitempty"`
+}
+
+type slogHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	opts   slog.HandlerOptions
+	attrs  []slogPrefixedAttr
+	groups []string
+	prefix string
+}
+
+type slogPrefixedAttr struct {
+	attr   slog.Attr
+	prefix string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+
+	return level >= minLevel
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	record := slogRecord{
+		Level:     slogLevelString(r.Level),
+		Timestamp: h.timestamp(r.Time),
+		Message:   r.Message,
+	}
+
+	for _, pa := range h.attrs {
+		h.addAttr(&record, pa.attr, pa.prefix)
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		h.addAttr(&record, a, h.prefix)
+		return true
+	})
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err = h.w.Write(append(b, '\n'))
+
+	return err
+}
+
+// timestamp converts the record time to typego.Timestamp. Like the slog built-in handlers, the time is passed to
+// ReplaceAttr with the slog.TimeKey key, so it can be changed or removed
+func (h *slogHandler) timestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return 0
+	}
+
+	if h.opts.ReplaceAttr != nil {
+		a := h.opts.ReplaceAttr(nil, slog.Time(slog.TimeKey, t))
+		a.Value = a.Value.Resolve()
+
+		if a.Key == "" {
+			return 0
+		}
+
+		if a.Value.Kind() == slog.KindTime {
+			t = a.Value.Time()
+		}
+	}
+
+	return NewTimestamp(t)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = make([]slogPrefixedAttr, 0, len(h.attrs)+len(attrs))
+	h2.attrs = append(h2.attrs, h.attrs...)
+
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, slogPrefixedAttr{attr: a, prefix: h.prefix})
+	}
+
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(append([]string(nil), h.groups...), name)
+	h2.prefix = strings.Join(h2.groups, ".") + "."
+
+	return &h2
+}
+
+func (h *slogHandler) addAttr(record *slogRecord, a slog.Attr, prefix string) {
+	if a.Value.Kind() == slog.KindLogValuer {
+		if err, ok := a.Value.Any().(Error); ok && prefix == "" {
+			mergeErrorRecord(record, err)
+			return
+		}
+	}
+
+	if h.opts.ReplaceAttr != nil && a.Value.Kind() != slog.KindGroup {
+		var groups []string
+
+		if prefix != "" {
+			groups = strings.Split(strings.TrimSuffix(prefix, "."), ".")
+		}
+
+		a = h.opts.ReplaceAttr(groups, a)
+	}
+
+	a.Value = a.Value.Resolve()
+
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if prefix == "" && a.Key == "fields" {
+			for _, ga := range a.Value.Group() {
+				h.addAttr(record, ga, "")
+			}
+
+			return
+		}
+
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = prefix + a.Key + "."
+		}
+
+		for _, ga := range a.Value.Group() {
+			h.addAttr(record, ga, groupPrefix)
+		}
+
+		return
+	}
+
+	if prefix == "" {
+		switch a.Key {
+		case "level":
+			return
+		case "message":
+			if record.Message == "" {
+				record.Message = a.Value.String()
+			} else if a.Value.String() != record.Message {
+				record.Info = append(record.Info, a.Value.String())
+			}
+
+			return
+		case "info", "debug", "stack":
+			if values, ok := a.Value.Any().([]string); ok {
+				switch a.Key {
+				case "info":
+					record.Info = append(record.Info, values...)
+				case "debug":
+					record.Debug = append(record.Debug, values...)
+				default:
+					record.Stack = append(record.Stack, values...)
+				}
+
+				return
+			}
+		case "process_id":
+			record.ProcessID = a.Value.String()
+			return
+		case "process_name":
+			record.ProcessName = a.Value.String()
+			return
+		case "code":
+			record.Code = a.Value.String()
+			return
+		case "http_status":
+			if a.Value.Kind() == slog.KindInt64 {
+				record.HttpStatus = int(a.Value.Int64())
+				return
+			}
+		case "rpc_status":
+			if a.Value.Kind() == slog.KindInt64 {
+				record.RPCStatus = int(a.Value.Int64())
+				return
+			}
+		}
+	}
+
+	if record.Fields == nil {
+		record.Fields = make(map[string]any)
+	}
+
+	record.Fields[prefix+a.Key] = a.Value.Any()
+}
+
+func mergeErrorRecord(record *slogRecord, err Error) {
+	if record.ProcessID == "" {
+		record.ProcessID = err.GetProcessID()
+	}
+
+	if record.ProcessName == "" {
+		record.ProcessName = err.GetProcessName()
+	}
+
+	if record.Code == "" {
+		record.Code = err.GetCode()
+	}
+
+	if record.HttpStatus == 0 {
+		record.HttpStatus = err.GetHttpStatus()
+	}
+
+	if record.RPCStatus == 0 {
+		record.RPCStatus = err.GetRPCStatus()
+	}
+
+	if record.Message == "" {
+		record.Message = err.GetMessage()
+	} else if err.GetMessage() != "" {
+		record.Info = append(record.Info, err.GetMessage())
+	}
+
+	if record.DevMessage == "" {
+		record.DevMessage = err.GetDeveloperMessage()
+	}
+
+	record.Info = append(record.Info, err.GetInfo()...)
+
+	for k, v := range err.GetFields() {
+		if record.Fields == nil {
+			record.Fields = make(map[string]any)
+		}
+
+		if _, ok := record.Fields[k]; !ok {
+			record.Fields[k] = v
+		}
+	}
+
+	record.Debug = append(record.Debug, err.GetDebug()...)
+	record.Stack = append(record.Stack, err.GetStack()...)
+}
+
+// errorAttrs converts the error to slog attributes. The level and message are included only when full is true, since
+// a slog record has its own level and message
+func errorAttrs(err Error, full bool) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 10)
+
+	if full {
+		attrs = append(attrs, slog.String("level", err.GetLevel()))
+
+		if t := err.GetTimestamp(); !t.IsZero() {
+			attrs = append(attrs, slog.Time("timestamp", t))
+		}
+	}
+
+	if processID := err.GetProcessID(); processID != "" {
+		attrs = append(attrs, slog.String("process_id", processID))
+	}
+
+	if processName := err.GetProcessName(); processName != "" {
+		attrs = append(attrs, slog.String("process_name", processName))
+	}
+
+	attrs = append(attrs, slog.String("code", err.GetCode()))
+
+	if full {
+		attrs = append(attrs, slog.String("message", err.GetMessage()))
+	}
+
+	if developerMessage := err.GetDeveloperMessage(); developerMessage != "" {
+		attrs = append(attrs, slog.String("developer_message", developerMessage))
+	}
+
+	if info := err.GetInfo(); len(info) > 0 {
+		attrs = append(attrs, slog.Any("info", info))
+	}
+
+	attrs = appendFieldAttrs(attrs, err.GetFields(), full)
+
+	if httpStatus := err.GetHttpStatus(); httpStatus != 0 {
+		attrs = append(attrs, slog.Int("http_status", httpStatus))
+	}
+
+	if rpcStatus := err.GetRPCStatus(); rpcStatus != 0 {
+		attrs = append(attrs, slog.Int("rpc_status", rpcStatus))
+	}
+
+	if debug := err.GetDebug(); len(debug) > 0 {
+		attrs = append(attrs, slog.Any("debug", debug))
+	}
+
+	if stack := err.GetStack(); len(stack) > 0 {
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+
+	if suppressed := err.GetSuppressed(); suppressed != 0 {
+		attrs = append(attrs, slog.Uint64("suppressed", suppressed))
+	}
+
+	return attrs
+}
+
+// infoAttrs converts the information to slog attributes. The level is included only when full is true, since a slog
+// record has its own level
+func infoAttrs(info Info, full bool) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 5)
+
+	if full {
+		attrs = append(attrs, slog.String("level", info.GetLevel()))
+
+		if t := info.GetTimestamp(); !t.IsZero() {
+			attrs = append(attrs, slog.Time("timestamp", t))
+		}
+	}
+
+	if processID := info.GetProcessID(); processID != "" {
+		attrs = append(attrs, slog.String("process_id", processID))
+	}
+
+	if processName := info.GetProcessName(); processName != "" {
+		attrs = append(attrs, slog.String("process_name", processName))
+	}
+
+	if i := info.GetInfo(); len(i) > 0 {
+		attrs = append(attrs, slog.Any("info", i))
+	}
+
+	attrs = appendFieldAttrs(attrs, info.GetFields(), full)
+
+	if debug := info.GetDebug(); len(debug) > 0 {
+		attrs = append(attrs, slog.Any("debug", debug))
+	}
+
+	if duration := info.GetDuration(); duration != 0 {
+		attrs = append(attrs, slog.Float64("duration_ms", float64(duration)/float64(time.Millisecond)))
+	}
+
+	if suppressed := info.GetSuppressed(); suppressed != 0 {
+		attrs = append(attrs, slog.Uint64("suppressed", suppressed))
+	}
+
+	return attrs
+}
+
+// appendFieldAttrs appends the fields sorted by key. The fields are grouped as `fields` when grouped is true,
+// otherwise, they are appended as top level attributes
+func appendFieldAttrs(attrs []slog.Attr, fields map[string]any, grouped bool) []slog.Attr {
+	if len(fields) == 0 {
+		return attrs
+	}
+
+	keys := make([]string, 0, len(fields))
+
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	fieldAttrs := make([]slog.Attr, 0, len(keys))
+
+	for _, k := range keys {
+		fieldAttrs = append(fieldAttrs, slog.Any(k, fields[k]))
+	}
+
+	if grouped {
+		return append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fieldAttrs...)})
+	}
+
+	return append(attrs, fieldAttrs...)
+}
+
+// slogLevel converts a typego level to slog.Level
+func slogLevel(level string) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelNotice:
+		return slogLevelNotice
+	case LevelWarning:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelFatal:
+		return slogLevelFatal
+	}
+
+	return slog.LevelInfo
+}
+
+// slogLevelString converts slog.Level to a typego level
+func slogLevelString(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slogLevelNotice:
+		return LevelInfo
+	case level < slog.LevelWarn:
+		return LevelNotice
+	case level < slog.LevelError:
+		return LevelWarning
+	case level < slogLevelFatal:
+		return LevelError
+	}
+
+	return LevelFatal
+}
@@ -0,0 +1,142 @@
+package typego_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestErrorModel_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "err", typego.NewError("01", "general error").SetHttpStatus(500).AddField("user_id", 1))
+
+	if output := buf.String(); !strings.Contains(output, "\"err\":{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"fields\":{\"user_id\":1},\"http_status\":500}") {
+		log.Fatal("`output` must contain `\"err\":{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"fields\":{\"user_id\":1},\"http_status\":500}`")
+	}
+}
+
+func TestInfoModel_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("done", "info", typego.NewInfo().SetProcessID("123").AddInfo("raw info"))
+
+	if output := buf.String(); !strings.Contains(output, "\"info\":{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"info\":[\"raw info\"]}") {
+		log.Fatal("`output` must contain `\"info\":{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_id\":\"123\",\"info\":[\"raw info\"]}`")
+	}
+}
+
+func TestNewSlogErrorLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	handler := typego.NewSlogErrorLog(slog.New(typego.NewSlogHandler(&buf, nil)))
+
+	handler(typego.NewError("01", "general error").SetHttpStatus(500).AddInfo("raw info"))
+
+	if output := buf.String(); output != "{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw info\"],\"http_status\":500}\n" {
+		log.Fatal("`output` must be `{\"level\":\"error\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw info\"],\"http_status\":500}`")
+	}
+
+	buf.Reset()
+
+	typego.NewSlogErrorLog(slog.New(slog.NewTextHandler(&buf, nil)))(typego.NewError("01", "general error"))
+
+	if output := buf.String(); !strings.Contains(output, "level=ERROR msg=\"general error\" code=01") {
+		log.Fatal("`output` must contain `level=ERROR msg=\"general error\" code=01`")
+	}
+}
+
+func TestNewSlogInfoLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	handler := typego.NewSlogInfoLog(slog.New(typego.NewSlogHandler(&buf, nil)))
+
+	handler(typego.NewInfo().SetProcessName("test").AddInfo("raw info").AddField("user_id", 1).AddDebug("raw debug"))
+
+	if output := buf.String(); output != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_name\":\"test\",\"info\":[\"raw info\"],\"fields\":{\"user_id\":1},\"debug\":[\"raw debug\"]}\n" {
+		log.Fatal("`output` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"process_name\":\"test\",\"info\":[\"raw info\"],\"fields\":{\"user_id\":1},\"debug\":[\"raw debug\"]}`")
+	}
+}
+
+func TestNewSlogHandler(t *testing.T) {
+	t.Run("attrs", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slog.New(typego.NewSlogHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeSlogTime})).With("process_id", "123")
+
+		logger.Warn("hello", "user_id", 1, slog.Group("req", "method", "GET"))
+
+		if output := buf.String(); output != "{\"level\":\"warning\",\"process_id\":\"123\",\"message\":\"hello\",\"info\":null,\"fields\":{\"req.method\":\"GET\",\"user_id\":1}}\n" {
+			log.Fatal("`output` must be `{\"level\":\"warning\",\"process_id\":\"123\",\"message\":\"hello\",\"info\":null,\"fields\":{\"req.method\":\"GET\",\"user_id\":1}}`")
+		}
+	})
+
+	t.Run("group", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		slog.New(typego.NewSlogHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeSlogTime})).WithGroup("req").With("method", "GET").Info("hello", "code", "01")
+
+		if output := buf.String(); output != "{\"level\":\"info\",\"message\":\"hello\",\"info\":null,\"fields\":{\"req.code\":\"01\",\"req.method\":\"GET\"}}\n" {
+			log.Fatal("`output` must be `{\"level\":\"info\",\"message\":\"hello\",\"info\":null,\"fields\":{\"req.code\":\"01\",\"req.method\":\"GET\"}}`")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		slog.New(typego.NewSlogHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeSlogTime})).Error("failed", "err", typego.Wrap(errors.New("raw error"), "01", "general error").AddDebug("raw debug"))
+
+		if output := buf.String(); output != "{\"level\":\"error\",\"code\":\"01\",\"message\":\"failed\",\"info\":[\"general error\"],\"debug\":[\"raw debug\"]}\n" {
+			log.Fatal("`output` must be `{\"level\":\"error\",\"code\":\"01\",\"message\":\"failed\",\"info\":[\"general error\"],\"debug\":[\"raw debug\"]}`")
+		}
+	})
+
+	t.Run("level", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slog.New(typego.NewSlogHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+		logger.Info("hello")
+
+		if output := buf.String(); output != "" {
+			log.Fatal("`output` must empty")
+		}
+	})
+
+	t.Run("replace_attr", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slog.New(typego.NewSlogHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == "password" {
+					return slog.String(a.Key, "***")
+				}
+
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Time(a.Key, testTime)
+				}
+
+				return a
+			},
+		}))
+
+		logger.Info("login", "password", "secret")
+
+		if output := buf.String(); output != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"message\":\"login\",\"info\":null,\"fields\":{\"password\":\"***\"}}\n" {
+			log.Fatal("`output` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"message\":\"login\",\"info\":null,\"fields\":{\"password\":\"***\"}}`")
+		}
+	})
+}
+
+// removeSlogTime removes the time of the slog records, so the output is deterministic
+func removeSlogTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+
+	return a
+}
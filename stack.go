@@ -0,0 +1,58 @@
+package typego
+
+import (
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+const defaultStackDepth = 32
+
+var stackCaptureEnabled atomic.Bool
+
+var stackDepth atomic.Int64
+
+func init() {
+	stackDepth.Store(defaultStackDepth)
+}
+
+// SetStackCapture enables or disables stack capture globally. When it is enabled, every typego.Error records the stack
+// trace of the place where it was created
+func SetStackCapture(enabled bool) {
+	stackCaptureEnabled.Store(enabled)
+}
+
+// SetStackDepth sets the maximum number of stack frames to capture. Zero or negative depth resets it to the default
+// depth (32)
+func SetStackDepth(depth int) {
+	if depth <= 0 {
+		depth = defaultStackDepth
+	}
+
+	stackDepth.Store(int64(depth))
+}
+
+// captureStack captures the stack trace of the caller. The skip is the number of frames to skip above the function
+// that calls captureStack
+func captureStack(skip int) []string {
+	pcs := make([]uintptr, stackDepth.Load())
+	n := runtime.Callers(skip+2, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := make([]string, 0, n)
+
+	for {
+		frame, more := frames.Next()
+
+		stack = append(stack, frame.File+":"+strconv.Itoa(frame.Line)+" "+frame.Function)
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
@@ -0,0 +1,62 @@
+package typego_test
+
+import (
+	"context"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestSetStackCapture(t *testing.T) {
+	typego.SetStackCapture(true)
+	defer typego.SetStackCapture(false)
+
+	errStack := typego.NewError("01", "general error").GetStack()
+
+	if len(errStack) == 0 {
+		log.Fatal("`errStack` must not empty")
+	}
+
+	if !strings.Contains(errStack[0], "stack_test.go") || !strings.Contains(errStack[0], "TestSetStackCapture") {
+		log.Fatal("`errStack[0]` must point to `TestSetStackCapture` in `stack_test.go`")
+	}
+
+	typego.SetStackCapture(false)
+
+	if errStack := typego.NewError("01", "general error").GetStack(); len(errStack) != 0 {
+		log.Fatal("`errStack` must empty")
+	}
+}
+
+func TestSetStackCapture_constructors(t *testing.T) {
+	typego.SetStackCapture(true)
+	defer typego.SetStackCapture(false)
+
+	errs := map[string]typego.Error{
+		"NewError":          typego.NewError("01", "general error"),
+		"Wrap":              typego.Wrap(errors.New("raw error"), "01", "general error"),
+		"NewErrorFromError": typego.NewErrorFromError(errors.New("raw error")),
+		"FromCode":          typego.FromCode("01"),
+		"NewErrorCtx":       typego.NewErrorCtx(context.Background(), "01", "general error"),
+		"ToError":           typego.ProblemDetails{Code: "01", Status: 500}.ToError(),
+	}
+
+	for name, err := range errs {
+		errStack := err.GetStack()
+
+		if len(errStack) == 0 || !strings.Contains(errStack[0], "TestSetStackCapture_constructors") {
+			log.Fatal("`errStack[0]` of `" + name + "` must point to `TestSetStackCapture_constructors`")
+		}
+	}
+}
+
+func TestSetStackDepth(t *testing.T) {
+	typego.SetStackDepth(1)
+	defer typego.SetStackDepth(0)
+
+	if errStackLen := len(typego.NewError("01", "general error").WithStack().GetStack()); errStackLen != 1 {
+		log.Fatal("`errStackLen` must be `1`")
+	}
+}
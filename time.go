@@ -0,0 +1,126 @@
+package typego
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// TimestampLayout decides how typego.Timestamp is serialized
+type TimestampLayout int
+
+const (
+	// TimestampRFC3339Nano serializes the timestamp as an RFC 3339 string with nanoseconds in UTC
+	TimestampRFC3339Nano TimestampLayout = iota
+
+	// TimestampUnixMilli serializes the timestamp as the number of milliseconds since the Unix epoch
+	TimestampUnixMilli
+)
+
+var timestampLayout atomic.Int64
+
+var clock atomic.Pointer[func() time.Time]
+
+// SetClock sets the function used to get the current time of the entries. A nil clock resets it to time.Now. It is
+// intended to be used by tests to get deterministic timestamps
+func SetClock(now func() time.Time) {
+	if now == nil {
+		clock.Store(nil)
+		return
+	}
+
+	clock.Store(&now)
+}
+
+// SetTimestampLayout sets the layout used to serialize the timestamps. The default layout is TimestampRFC3339Nano
+func SetTimestampLayout(layout TimestampLayout) {
+	timestampLayout.Store(int64(layout))
+	encodingGeneration.Add(1)
+}
+
+// now gets the current time from the clock
+func now() time.Time {
+	if c := clock.Load(); c != nil {
+		return (*c)()
+	}
+
+	return time.Now()
+}
+
+// Timestamp is the number of nanoseconds since the Unix epoch. The zero Timestamp means the time is not set, so it is
+// omitted by the `omitempty` JSON option
+type Timestamp int64
+
+// NewTimestamp generates new typego.Timestamp from t. The zero time gives the zero Timestamp
+func NewTimestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return 0
+	}
+
+	return Timestamp(t.UnixNano())
+}
+
+// Time converts the timestamp to time.Time in UTC. The zero Timestamp gives the zero time
+func (t Timestamp) Time() time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, int64(t)).UTC()
+}
+
+// IsZero reports whether the timestamp is not set
+func (t Timestamp) IsZero() bool {
+	return t == 0
+}
+
+// String returns the timestamp in RFC 3339 format with nanoseconds
+func (t Timestamp) String() string {
+	return t.Time().Format(time.RFC3339Nano)
+}
+
+// MarshalJSON serializes the timestamp according to the layout set by SetTimestampLayout
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if TimestampLayout(timestampLayout.Load()) == TimestampUnixMilli {
+		return strconv.AppendInt(nil, t.Time().UnixMilli(), 10), nil
+	}
+
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON deserializes the timestamp from an RFC 3339 string or the number of milliseconds since the Unix epoch,
+// regardless of the layout set by SetTimestampLayout
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		parsed, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("typego: invalid timestamp %s: %w", s, err)
+		}
+
+		*t = NewTimestamp(parsed)
+
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("typego: invalid timestamp %s: %w", b, err)
+	}
+
+	*t = NewTimestamp(time.UnixMilli(ms))
+
+	return nil
+}
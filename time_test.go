@@ -0,0 +1,125 @@
+package typego_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestSetClock(t *testing.T) {
+	defer typego.SetClock(func() time.Time {
+		return testTime
+	})
+
+	typego.SetClock(nil)
+
+	if timestamp := typego.NewError("01", "").GetTimestamp(); time.Since(timestamp) > time.Minute {
+		log.Fatal("`timestamp` must be the current time")
+	}
+
+	typego.SetClock(func() time.Time {
+		return testTime.Add(time.Hour)
+	})
+
+	if timestamp := typego.NewInfo().GetTimestamp(); !timestamp.Equal(testTime.Add(time.Hour)) {
+		log.Fatal("`timestamp` must be `2024-01-01T01:00:00Z`")
+	}
+}
+
+func TestSetTimestampLayout(t *testing.T) {
+	defer typego.SetTimestampLayout(typego.TimestampRFC3339Nano)
+
+	typego.SetTimestampLayout(typego.TimestampUnixMilli)
+
+	if err := typego.NewError("01", "general error").Error(); err != "{\"level\":\"error\",\"timestamp\":1704067200000,\"code\":\"01\",\"message\":\"general error\",\"info\":null}" {
+		log.Fatal("`err` must be `{\"level\":\"error\",\"timestamp\":1704067200000,\"code\":\"01\",\"message\":\"general error\",\"info\":null}`")
+	}
+}
+
+func TestNewTimestamp(t *testing.T) {
+	if timestamp := typego.NewTimestamp(time.Time{}); !timestamp.IsZero() {
+		log.Fatal("`timestamp` must be zero")
+	}
+
+	if timestamp := typego.NewTimestamp(testTime); !timestamp.Time().Equal(testTime) {
+		log.Fatal("`timestamp` must be `2024-01-01T00:00:00Z`")
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	timestamp := typego.NewTimestamp(testTime.Add(123456789 * time.Nanosecond))
+
+	if b, _ := json.Marshal(timestamp); string(b) != "\"2024-01-01T00:00:00.123456789Z\"" {
+		log.Fatal("`timestamp` must be `\"2024-01-01T00:00:00.123456789Z\"`")
+	}
+
+	typego.SetTimestampLayout(typego.TimestampUnixMilli)
+	defer typego.SetTimestampLayout(typego.TimestampRFC3339Nano)
+
+	if b, _ := json.Marshal(timestamp); string(b) != "1704067200123" {
+		log.Fatal("`timestamp` must be `1704067200123`")
+	}
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	var timestamp typego.Timestamp
+
+	if err := json.Unmarshal([]byte("\"2024-01-01T00:00:00.5Z\""), &timestamp); err != nil || !timestamp.Time().Equal(testTime.Add(500*time.Millisecond)) {
+		log.Fatal("`timestamp` must be `2024-01-01T00:00:00.5Z`")
+	}
+
+	if err := json.Unmarshal([]byte("1704067200123"), &timestamp); err != nil || !timestamp.Time().Equal(testTime.Add(123*time.Millisecond)) {
+		log.Fatal("`timestamp` must be `2024-01-01T00:00:00.123Z`")
+	}
+
+	if err := json.Unmarshal([]byte("\"yesterday\""), &timestamp); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+
+	if err := json.Unmarshal([]byte("true"), &timestamp); err == nil {
+		log.Fatal("`err` must not nil")
+	}
+}
+
+func TestErrorModel_GetTimestamp(t *testing.T) {
+	if timestamp := typego.NewError("01", "").GetTimestamp(); !timestamp.Equal(testTime) {
+		log.Fatal("`timestamp` must be `2024-01-01T00:00:00Z`")
+	}
+
+	if timestamp := typego.NewErrorFromError(errors.New("{\"code\":\"01\",\"timestamp\":1704067200000}")).GetTimestamp(); !timestamp.Equal(testTime) {
+		log.Fatal("`timestamp` must be `2024-01-01T00:00:00Z`")
+	}
+}
+
+func TestInfoModel_Finish(t *testing.T) {
+	defer typego.SetClock(func() time.Time {
+		return testTime
+	})
+
+	info := typego.NewInfo()
+
+	typego.SetClock(func() time.Time {
+		return testTime.Add(time.Second)
+	})
+
+	started := info.Start()
+
+	typego.SetClock(func() time.Time {
+		return testTime.Add(1500 * time.Millisecond)
+	})
+
+	if duration := info.Finish().GetDuration(); duration != 1500*time.Millisecond {
+		log.Fatal("`duration` must be `1.5s`")
+	}
+
+	if s := started.Finish().String(); s != "{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null,\"duration_ms\":500}" {
+		log.Fatal("`s` must be `{\"level\":\"info\",\"timestamp\":\"2024-01-01T00:00:00Z\",\"info\":null,\"duration_ms\":500}`")
+	}
+
+	if duration := info.GetDuration(); duration != 0 {
+		log.Fatal("`duration` must be `0`")
+	}
+}
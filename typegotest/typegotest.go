@@ -0,0 +1,274 @@
+// Package typegotest provides helpers to capture and assert typego entries in tests
+package typegotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dalikewara/typego"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGoldenJSON write the golden files instead of comparing
+// them, for example: `TYPEGO_UPDATE_GOLDEN=1 go test ./...`
+const UpdateGoldenEnv = "TYPEGO_UPDATE_GOLDEN"
+
+// Recorder captures the entries logged to the default pipeline during a test
+type Recorder struct {
+	t         testing.TB
+	name      string
+	processID string
+
+	mu      sync.Mutex
+	entries []typego.Entry
+}
+
+// NewRecorder generates new typegotest.Recorder that captures every entry logged to the default pipeline. The recorder
+// is removed from the pipeline when the test finishes
+func NewRecorder(t testing.TB) *Recorder {
+	return newRecorder(t, "")
+}
+
+// NewScopedRecorder generates new typegotest.Recorder that captures only the entries with the process id, so parallel
+// tests logging to the same pipeline do not see each other's entries. The recorder is removed from the pipeline when
+// the test finishes
+func NewScopedRecorder(t testing.TB, processID string) *Recorder {
+	return newRecorder(t, processID)
+}
+
+func newRecorder(t testing.TB, processID string) *Recorder {
+	t.Helper()
+
+	r := &Recorder{
+		t:         t,
+		processID: processID,
+	}
+
+	r.name = fmt.Sprintf("typegotest:%s:%p", t.Name(), r)
+
+	p := typego.DefaultPipeline()
+
+	if err := p.AddSink(r.name, r); err != nil {
+		t.Fatalf("typegotest: add recorder: %v", err)
+	}
+
+	t.Cleanup(func() {
+		p.RemoveSink(r.name)
+	})
+
+	return r
+}
+
+// Write records the entry. It implements typego.Sink
+func (r *Recorder) Write(entry typego.Entry) error {
+	if r.processID != "" && entry.GetProcessID() != r.processID {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+// Entries returns the recorded entries
+func (r *Recorder) Entries() []typego.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]typego.Entry(nil), r.entries...)
+}
+
+// Errors returns the recorded errors
+func (r *Recorder) Errors() []typego.Error {
+	var errs []typego.Error
+
+	for _, entry := range r.Entries() {
+		if err, ok := entry.(typego.Error); ok {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Infos returns the recorded information
+func (r *Recorder) Infos() []typego.Info {
+	var infos []typego.Info
+
+	for _, entry := range r.Entries() {
+		if info, ok := entry.(typego.Info); ok {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
+
+// Reset removes the recorded entries
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = nil
+}
+
+// AssertLogged asserts that an error with the code has been recorded
+func (r *Recorder) AssertLogged(code string) bool {
+	r.t.Helper()
+
+	codes := make([]string, 0)
+
+	for _, err := range r.Errors() {
+		if err.GetCode() == code {
+			return true
+		}
+
+		codes = append(codes, err.GetCode())
+	}
+
+	r.t.Errorf("typegotest: error with code %q is not logged, logged codes: %q", code, codes)
+
+	return false
+}
+
+// AssertNotLogged asserts that no error with the code has been recorded
+func (r *Recorder) AssertNotLogged(code string) bool {
+	r.t.Helper()
+
+	for _, err := range r.Errors() {
+		if err.GetCode() == code {
+			r.t.Errorf("typegotest: error with code %q is logged", code)
+			return false
+		}
+	}
+
+	return true
+}
+
+// AssertCode asserts that the error chain contains a typego.Error with the code
+func AssertCode(t testing.TB, err error, code string) bool {
+	t.Helper()
+
+	e, ok := asError(t, err)
+	if !ok {
+		return false
+	}
+
+	if e.GetCode() != code {
+		t.Errorf("typegotest: code is %q, want %q", e.GetCode(), code)
+		return false
+	}
+
+	return true
+}
+
+// AssertHTTPStatus asserts that the error chain contains a typego.Error with the http status
+func AssertHTTPStatus(t testing.TB, err error, status int) bool {
+	t.Helper()
+
+	e, ok := asError(t, err)
+	if !ok {
+		return false
+	}
+
+	if e.GetHttpStatus() != status {
+		t.Errorf("typegotest: http status is %d, want %d", e.GetHttpStatus(), status)
+		return false
+	}
+
+	return true
+}
+
+// AssertInfoContains asserts that an information of the entry contains the substring
+func AssertInfoContains(t testing.TB, entry typego.Entry, substr string) bool {
+	t.Helper()
+
+	if entry == nil {
+		t.Errorf("typegotest: entry is nil, want information containing %q", substr)
+		return false
+	}
+
+	for _, info := range entry.GetInfo() {
+		if strings.Contains(info, substr) {
+			return true
+		}
+	}
+
+	t.Errorf("typegotest: information %q does not contain %q", entry.GetInfo(), substr)
+
+	return false
+}
+
+// AssertGoldenJSON asserts that the JSON encoding of v is equal to the golden file `testdata/<name>.golden.json`. The
+// JSON values are compared regardless of formatting. If the UpdateGoldenEnv environment variable is set, it writes the
+// golden file instead. Use typego.SetClock to get deterministic timestamps
+func AssertGoldenJSON(t testing.TB, name string, v any) bool {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Errorf("typegotest: encode %s: %v", name, err)
+		return false
+	}
+
+	path := filepath.Join("testdata", name+".golden.json")
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, append(got, '\n'), 0o644)
+		}
+
+		if err != nil {
+			t.Errorf("typegotest: update golden file %s: %v", path, err)
+			return false
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("typegotest: read golden file %s: %v, set %s=1 to create it", path, err, UpdateGoldenEnv)
+		return false
+	}
+
+	if !jsonEqual(got, want) {
+		t.Errorf("typegotest: %s does not match the golden file %s\ngot:\n%s\nwant:\n%s", name, path, got, bytes.TrimSpace(want))
+		return false
+	}
+
+	return true
+}
+
+func asError(t testing.TB, err error) (typego.Error, bool) {
+	t.Helper()
+
+	var e typego.Error
+
+	if !errors.As(err, &e) {
+		t.Errorf("typegotest: %v is not a typego.Error", err)
+		return nil, false
+	}
+
+	return e, true
+}
+
+func jsonEqual(a []byte, b []byte) bool {
+	var av, bv any
+
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
@@ -0,0 +1,197 @@
+package typegotest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dalikewara/typego"
+	"github.com/dalikewara/typego/typegotest"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+// fakeTB records the failures instead of failing the test
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failed = true
+}
+
+func TestMain(m *testing.M) {
+	typego.SetClock(func() time.Time {
+		return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	typego.DefaultPipeline().RemoveSink(typego.DefaultSinkName)
+
+	os.Exit(m.Run())
+}
+
+func TestNewRecorder(t *testing.T) {
+	rec := typegotest.NewRecorder(t)
+
+	typego.NewError("01", "general error").Log()
+	typego.NewInfo().AddInfo("raw info").Log()
+
+	if entries := rec.Entries(); len(entries) != 2 {
+		log.Fatal("`entries` must have 2 entries")
+	}
+
+	if errs := rec.Errors(); len(errs) != 1 || errs[0].GetCode() != "01" {
+		log.Fatal("`errs` must be `[01]`")
+	}
+
+	if infos := rec.Infos(); len(infos) != 1 || infos[0].GetInfo()[0] != "raw info" {
+		log.Fatal("`infos` must be `[raw info]`")
+	}
+
+	rec.Reset()
+
+	if entries := rec.Entries(); len(entries) != 0 {
+		log.Fatal("`entries` must be empty")
+	}
+
+	t.Run("cleanup", func(t *testing.T) {
+		typegotest.NewRecorder(t)
+	})
+
+	if sinks := typego.DefaultPipeline().Sinks(); len(sinks) != 1 {
+		log.Fatal("`sinks` must have the recorder of the parent test only")
+	}
+}
+
+func TestNewScopedRecorder(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		processID := fmt.Sprintf("process-%d", i)
+
+		t.Run(processID, func(t *testing.T) {
+			t.Parallel()
+
+			rec := typegotest.NewScopedRecorder(t, processID)
+			ctx := typego.WithProcessID(context.Background(), processID)
+
+			typego.NewError("01", "general error").LogCtx(ctx)
+			typego.NewError("02", "general error").Log()
+
+			if errs := rec.Errors(); len(errs) != 1 || errs[0].GetProcessID() != processID {
+				log.Fatal("`errs` must have the error of the process only")
+			}
+		})
+	}
+}
+
+func TestRecorder_AssertLogged(t *testing.T) {
+	rec := typegotest.NewRecorder(t)
+
+	typego.NewError("01", "general error").Log()
+
+	if !rec.AssertLogged("01") {
+		log.Fatal("`01` must be logged")
+	}
+
+	tb := &fakeTB{TB: t}
+
+	if typegotest.NewRecorder(tb).AssertLogged("02"); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+}
+
+func TestRecorder_AssertNotLogged(t *testing.T) {
+	rec := typegotest.NewRecorder(t)
+
+	typego.NewError("01", "general error").Log()
+
+	if !rec.AssertNotLogged("02") {
+		log.Fatal("`02` must not be logged")
+	}
+
+	tb := &fakeTB{TB: t}
+	rec = typegotest.NewRecorder(tb)
+
+	typego.NewError("01", "general error").Log()
+
+	if rec.AssertNotLogged("01"); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+}
+
+func TestAssertCode(t *testing.T) {
+	err := fmt.Errorf("query user: %w", typego.NewError("01", "general error"))
+
+	if !typegotest.AssertCode(t, err, "01") {
+		log.Fatal("`err` must have code `01`")
+	}
+
+	tb := &fakeTB{TB: t}
+
+	if typegotest.AssertCode(tb, err, "02"); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+
+	tb = &fakeTB{TB: t}
+
+	if typegotest.AssertCode(tb, errors.New("raw error"), "01"); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+}
+
+func TestAssertHTTPStatus(t *testing.T) {
+	err := typego.NewError("01", "not found").SetHttpStatus(404)
+
+	if !typegotest.AssertHTTPStatus(t, err, 404) {
+		log.Fatal("`err` must have http status `404`")
+	}
+
+	tb := &fakeTB{TB: t}
+
+	if typegotest.AssertHTTPStatus(tb, err, 500); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+}
+
+func TestAssertInfoContains(t *testing.T) {
+	err := typego.NewError("01", "general error").AddInfo("connection refused")
+
+	if !typegotest.AssertInfoContains(t, err, "refused") {
+		log.Fatal("`err` must have information containing `refused`")
+	}
+
+	tb := &fakeTB{TB: t}
+
+	if typegotest.AssertInfoContains(tb, err, "timeout"); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+
+	tb = &fakeTB{TB: t}
+
+	if typegotest.AssertInfoContains(tb, nil, "timeout"); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+}
+
+func TestAssertGoldenJSON(t *testing.T) {
+	err := typego.NewError("01", "general error").AddInfo("raw info").AddField("user_id", 1).SetHttpStatus(500)
+
+	if !typegotest.AssertGoldenJSON(t, "error", err) {
+		log.Fatal("`err` must match the golden file")
+	}
+
+	tb := &fakeTB{TB: t}
+
+	if typegotest.AssertGoldenJSON(tb, "error", err.ChangeCode("02")); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+
+	tb = &fakeTB{TB: t}
+
+	if typegotest.AssertGoldenJSON(tb, "missing", err); !tb.failed {
+		log.Fatal("`tb` must fail")
+	}
+}
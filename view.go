@@ -0,0 +1,11 @@
+package typego
+
+// publicError is the client facing view of typego.Error. It is the view to use at the API boundaries, while Log()
+// records the complete error
+type publicError struct {
+	ProcessID string   `json:"process_id,omitempty"`
+	Code      string   `json:"code"`
+	Message   string   `json:"message"`
+	Info      []string `json:"info"`
+	infoRaw   []bool
+}
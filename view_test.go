@@ -0,0 +1,66 @@
+package typego_test
+
+import (
+	"errors"
+	"github.com/dalikewara/typego"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorModel_PublicJSON_boundary(t *testing.T) {
+	err := typego.NewError("01", "general error").SetDeveloperMessage("database timeout").AddInfo("raw error").AddDebug("debug")
+	public := "{\"code\":\"01\",\"message\":\"general error\",\"info\":[\"raw error\"]}"
+
+	if errString := err.Error(); errString != err.InternalJSON() {
+		log.Fatal("`errString` must be the internal json")
+	}
+
+	if errPublic := err.PublicJSON(); errPublic != public {
+		log.Fatal("`errPublic` must be `" + public + "`")
+	}
+
+	parsed := typego.NewErrorFromError(errors.New(err.SetHttpStatus(404).SetRPCStatus(5).Error()))
+
+	if parsed.GetHttpStatus() != 404 || parsed.GetRPCStatus() != 5 || parsed.GetLevel() != typego.LevelError {
+		log.Fatal("`parsed` must keep the level, http status and rpc status")
+	}
+
+	if problem := typego.NewProblemDetails(err); problem.Debug != nil {
+		log.Fatal("`problem.Debug` must be nil")
+	}
+
+	if b, _ := typego.Fail[int](err).MarshalJSON(); string(b) != "{\"error\":"+public+"}" {
+		log.Fatal("`b` must be `{\"error\":" + public + "}`")
+	}
+
+	if s := typego.NewMultiError(err).PublicJSON(); s != "["+public+"]" {
+		log.Fatal("`s` must be `[" + public + "]`")
+	}
+
+	rec := httptest.NewRecorder()
+
+	typego.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return err
+	}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if body := rec.Body.String(); strings.Contains(body, "database timeout") || strings.Contains(body, "debug") {
+		log.Fatal("`body` must not contain the developer message and debug information")
+	}
+
+	var logged typego.Error
+
+	_ = typego.DefaultPipeline().AddSink("public_view", typego.SinkFunc(func(entry typego.Entry) error {
+		logged, _ = entry.(typego.Error)
+		return nil
+	}))
+	defer typego.DefaultPipeline().RemoveSink("public_view")
+
+	err.Log()
+
+	if logged == nil || logged.GetDeveloperMessage() != "database timeout" || len(logged.GetDebug()) != 1 {
+		log.Fatal("`logged` must be the complete error")
+	}
+}